@@ -0,0 +1,27 @@
+// Package actor identifica, pelo contexto da requisição, quem executa uma operação. É o ator
+// gravado na trilha de auditoria.
+package actor
+
+import "context"
+
+const (
+	// Anonymous é o ator das requisições sem identificação.
+	Anonymous = "anonimo"
+	// System é o ator das operações executadas pela própria aplicação, como recálculos e expurgos.
+	System = "sistema"
+)
+
+type contextKey struct{}
+
+// WithActor retorna uma cópia de ctx identificando o ator informado.
+func WithActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+// FromContext retorna o ator do contexto, ou Anonymous se ele não foi identificado.
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(contextKey{}).(string); ok && name != "" {
+		return name
+	}
+	return Anonymous
+}
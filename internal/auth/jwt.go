@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidToken = errors.New("token inválido")
+	ErrExpiredToken = errors.New("token expirado")
+)
+
+// Verifier valida tokens JWT compactos (JWS) com as chaves e as claims da configuração.
+type Verifier struct {
+	config Config
+	parser *jwt.Parser
+}
+
+// NewVerifier aceita apenas os algoritmos das chaves (HS256 e RS256), com a tolerância de
+// relógio da configuração, e exige a claim exp e, quando configurados, iss e aud.
+func NewVerifier(config Config) *Verifier {
+	return newVerifier(config, time.Now)
+}
+
+func newVerifier(config Config, now func() time.Time) *Verifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{HS256, RS256}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+		jwt.WithTimeFunc(now),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	return &Verifier{config, jwt.NewParser(options...)}
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Name        string   `json:"name"`
+	Roles       []string `json:"roles"`
+	ProfessorID *int     `json:"professor_id"`
+	AlunoIDs    []int    `json:"aluno_ids"`
+}
+
+// Verify confere a assinatura e as claims do token e retorna o usuário autenticado. São exigidos
+// sub e exp; o algoritmo do cabeçalho precisa ser o da chave, para que uma chave RS256 não seja
+// usada como segredo HS256.
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	var claims tokenClaims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.keys); err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, fmt.Errorf("%w: %v", ErrExpiredToken, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: claim sub ausente", ErrInvalidToken)
+	}
+	return &Principal{
+		Subject:     claims.Subject,
+		Name:        claims.Name,
+		Roles:       claims.Roles,
+		ProfessorID: claims.ProfessorID,
+		AlunoIDs:    claims.AlunoIDs,
+	}, nil
+}
+
+// keys retorna as chaves do algoritmo do token, restritas ao kid quando o token e a chave o têm.
+func (v *Verifier) keys(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	var set jwt.VerificationKeySet
+	for _, key := range v.config.Keys {
+		if key.Algorithm != token.Method.Alg() || (key.ID != "" && kid != "" && key.ID != kid) {
+			continue
+		}
+		set.Keys = append(set.Keys, key.verificationKey())
+	}
+	if len(set.Keys) == 0 {
+		return nil, errors.New("nenhuma chave para o algoritmo e o kid do token")
+	}
+	return set, nil
+}
+
+func (k Key) verificationKey() jwt.VerificationKey {
+	if k.Algorithm == RS256 {
+		return k.publicKey
+	}
+	return k.secret
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "segredo-de-teste-com-mais-de-32-bytes"
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func generateRSAKey(t *testing.T, bits int) (*rsa.PrivateKey, string) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privateKey, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestVerify(t *testing.T) {
+	privateKey, publicPEM := generateRSAKey(t, 2048)
+	otherKey, _ := generateRSAKey(t, 2048)
+	keys, err := parseKeys([]byte(fmt.Sprintf(`{"keys": [
+		{"kid": "app", "alg": "HS256", "secret": %q},
+		{"kid": "sso", "alg": "RS256", "public_key": %q}
+	]}`, testSecret, publicPEM)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := newVerifier(Config{Keys: keys, Issuer: "escola", Audience: "api", Leeway: 30 * time.Second},
+		func() time.Time { return testNow })
+
+	claims := func(change func(c jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub": "ana", "name": "Ana", "roles": []string{"professor"}, "professor_id": 3,
+			"iss": "escola", "aud": "api", "exp": testNow.Add(time.Hour).Unix(),
+		}
+		if change != nil {
+			change(c)
+		}
+		return c
+	}
+	hs256 := func(c jwt.MapClaims) string { return sign(t, jwt.SigningMethodHS256, "app", []byte(testSecret), c) }
+	rs256 := func(c jwt.MapClaims) string { return sign(t, jwt.SigningMethodRS256, "sso", privateKey, c) }
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "HS256 válido", token: hs256(claims(nil))},
+		{name: "RS256 válido", token: rs256(claims(nil))},
+		{name: "HS256 sem kid", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(nil))},
+		{name: "aud em lista", token: hs256(claims(func(c jwt.MapClaims) { c["aud"] = []string{"outra", "api"} }))},
+		{name: "expirado dentro da tolerância", token: hs256(claims(func(c jwt.MapClaims) { c["exp"] = testNow.Add(-10 * time.Second).Unix() }))},
+		{
+			name:    "expirado",
+			token:   hs256(claims(func(c jwt.MapClaims) { c["exp"] = testNow.Add(-time.Minute).Unix() })),
+			wantErr: ErrExpiredToken,
+		},
+		{name: "sem exp", token: hs256(claims(func(c jwt.MapClaims) { delete(c, "exp") })), wantErr: ErrInvalidToken},
+		{
+			name:    "nbf no futuro",
+			token:   hs256(claims(func(c jwt.MapClaims) { c["nbf"] = testNow.Add(time.Minute).Unix() })),
+			wantErr: ErrInvalidToken,
+		},
+		{name: "nbf dentro da tolerância", token: hs256(claims(func(c jwt.MapClaims) { c["nbf"] = testNow.Add(10 * time.Second).Unix() }))},
+		{name: "sem sub", token: hs256(claims(func(c jwt.MapClaims) { delete(c, "sub") })), wantErr: ErrInvalidToken},
+		{name: "iss diferente", token: hs256(claims(func(c jwt.MapClaims) { c["iss"] = "outra" })), wantErr: ErrInvalidToken},
+		{name: "aud diferente", token: hs256(claims(func(c jwt.MapClaims) { c["aud"] = "outra" })), wantErr: ErrInvalidToken},
+		{
+			name:    "assinatura HS256 com outro segredo",
+			token:   sign(t, jwt.SigningMethodHS256, "app", []byte(strings.Repeat("x", 32)), claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "assinatura RS256 com outra chave",
+			token:   sign(t, jwt.SigningMethodRS256, "sso", otherKey, claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{name: "assinatura adulterada", token: tamper(hs256(claims(nil))), wantErr: ErrInvalidToken},
+		{
+			name:    "confusão de algoritmo: HS256 assinado com a chave pública RS256",
+			token:   sign(t, jwt.SigningMethodHS256, "sso", []byte(publicPEM), claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "confusão de algoritmo: HS256 sem kid assinado com a chave pública RS256",
+			token:   sign(t, jwt.SigningMethodHS256, "", []byte(publicPEM), claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{name: "alg none", token: sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, claims(nil)), wantErr: ErrInvalidToken},
+		{
+			name:    "algoritmo fora da lista (HS512)",
+			token:   sign(t, jwt.SigningMethodHS512, "app", []byte(testSecret), claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "algoritmo fora da lista (PS256)",
+			token:   sign(t, jwt.SigningMethodPS256, "sso", privateKey, claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{name: "kid desconhecido", token: sign(t, jwt.SigningMethodHS256, "outro", []byte(testSecret), claims(nil)), wantErr: ErrInvalidToken},
+		{name: "malformado", token: "não.é.jwt", wantErr: ErrInvalidToken},
+		{name: "dois segmentos", token: "e30.e30", wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if principal.Subject != "ana" || principal.Name != "Ana" || !principal.HasRole("professor") ||
+				principal.ProfessorID == nil || *principal.ProfessorID != 3 {
+				t.Errorf("principal = %+v, want ana (professor 3)", principal)
+			}
+		})
+	}
+}
+
+// tamper troca o primeiro byte da assinatura do token.
+func tamper(token string) string {
+	i := strings.LastIndex(token, ".")
+	signature, _ := base64.RawURLEncoding.DecodeString(token[i+1:])
+	signature[0] ^= 0xff
+	return token[:i+1] + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestParseKeys(t *testing.T) {
+	_, strongPEM := generateRSAKey(t, 2048)
+	_, weakPEM := generateRSAKey(t, 1024)
+	tests := []struct {
+		name    string
+		keys    string
+		wantErr string
+	}{
+		{name: "HS256", keys: fmt.Sprintf(`{"keys": [{"alg": "HS256", "secret": %q}]}`, testSecret)},
+		{name: "RS256 de 2048 bits", keys: fmt.Sprintf(`{"keys": [{"alg": "RS256", "public_key": %q}]}`, strongPEM)},
+		{
+			name:    "RS256 de 1024 bits",
+			keys:    fmt.Sprintf(`{"keys": [{"kid": "fraca", "alg": "RS256", "public_key": %q}]}`, weakPEM),
+			wantErr: "1024 bits",
+		},
+		{name: "segredo curto", keys: `{"keys": [{"alg": "HS256", "secret": "curto"}]}`, wantErr: "ao menos 32 bytes"},
+		{name: "algoritmo não suportado", keys: `{"keys": [{"alg": "none"}]}`, wantErr: "algoritmo não suportado"},
+		{name: "vazio", keys: `{"keys": []}`, wantErr: "vazio"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseKeys([]byte(tt.keys))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("parseKeys: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseKeys error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Algoritmos de assinatura aceitos.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// minSecretLength é o tamanho mínimo, em bytes, dos segredos HS256 (o tamanho do hash SHA-256),
+// e minRSAKeyBits, o das chaves RS256.
+const (
+	minSecretLength = 32
+	minRSAKeyBits   = 2048
+)
+
+// Key é uma chave de verificação de tokens. ID corresponde ao kid do cabeçalho do token;
+// uma chave sem ID pode verificar qualquer token do seu algoritmo.
+type Key struct {
+	ID        string
+	Algorithm string
+	secret    []byte
+	publicKey *rsa.PublicKey
+}
+
+// Config são as chaves aceitas e as claims exigidas dos tokens. Issuer e Audience vazios não
+// são verificados.
+type Config struct {
+	Keys     []Key
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// keyFile é o formato do conjunto de chaves em JWT_KEYS ou no arquivo JWT_KEYS_FILE:
+//
+//	{"keys": [
+//	  {"kid": "app", "alg": "HS256", "secret": "..."},
+//	  {"kid": "sso", "alg": "RS256", "public_key": "-----BEGIN PUBLIC KEY-----\n..."}
+//	]}
+type keyFile struct {
+	Keys []struct {
+		ID        string `json:"kid"`
+		Algorithm string `json:"alg"`
+		Secret    string `json:"secret"`
+		PublicKey string `json:"public_key"`
+	} `json:"keys"`
+}
+
+// LoadConfig lê o conjunto de chaves da variável JWT_KEYS ou do arquivo indicado em
+// JWT_KEYS_FILE, e as claims exigidas de JWT_ISSUER e JWT_AUDIENCE. Sem chaves, nenhum
+// token seria aceito, então a configuração é inválida.
+func LoadConfig() (Config, error) {
+	c := Config{
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+		Leeway:   30 * time.Second,
+	}
+
+	data := []byte(os.Getenv("JWT_KEYS"))
+	if path := os.Getenv("JWT_KEYS_FILE"); len(data) == 0 && path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return c, fmt.Errorf("JWT_KEYS_FILE: %w", err)
+		}
+	}
+	if len(data) == 0 {
+		return c, errors.New("nenhuma chave de verificação de tokens configurada (JWT_KEYS ou JWT_KEYS_FILE)")
+	}
+
+	keys, err := parseKeys(data)
+	if err != nil {
+		return c, err
+	}
+	c.Keys = keys
+	return c, nil
+}
+
+func parseKeys(data []byte) ([]Key, error) {
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("conjunto de chaves inválido: %w", err)
+	}
+	if len(file.Keys) == 0 {
+		return nil, errors.New("o conjunto de chaves está vazio")
+	}
+
+	keys := make([]Key, len(file.Keys))
+	for i, k := range file.Keys {
+		key := Key{ID: k.ID, Algorithm: k.Algorithm}
+		switch k.Algorithm {
+		case HS256:
+			if len(k.Secret) < minSecretLength {
+				return nil, fmt.Errorf("chave %d (%q): o segredo HS256 deve ter ao menos %d bytes", i, k.ID, minSecretLength)
+			}
+			key.secret = []byte(k.Secret)
+		case RS256:
+			publicKey, err := parseRSAPublicKey(k.PublicKey)
+			if err == nil {
+				err = checkRSAKeySize(publicKey)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("chave %d (%q): %w", i, k.ID, err)
+			}
+			key.publicKey = publicKey
+		default:
+			return nil, fmt.Errorf("chave %d (%q): algoritmo não suportado %q", i, k.ID, k.Algorithm)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// parseRSAPublicKey aceita chaves públicas PEM nos formatos PKIX ("PUBLIC KEY") e PKCS #1
+// ("RSA PUBLIC KEY").
+func parseRSAPublicKey(data string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("chave pública RS256 não está no formato PEM")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("a chave pública não é RSA")
+	}
+	return publicKey, nil
+}
+
+func checkRSAKeySize(publicKey *rsa.PublicKey) error {
+	if bits := publicKey.N.BitLen(); bits < minRSAKeyBits {
+		return fmt.Errorf("a chave RS256 tem %d bits, o mínimo é %d", bits, minRSAKeyBits)
+	}
+	return nil
+}
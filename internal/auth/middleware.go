@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/actor"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// publicPaths são as rotas acessíveis sem autenticação, com as suas sub-rotas: a documentação,
+// o catálogo de problemas, a verificação de saúde e as métricas (que têm um token próprio),
+// além da raiz (que redireciona para a documentação).
+var publicPaths = []string{"/swagger", "/problems", "/health", "/metrics"}
+
+// isPublic compara segmentos inteiros do caminho, para que /healthz-admin ou /metricsX não
+// sejam confundidos com /health e /metrics.
+func isPublic(path string) bool {
+	if path == "/" {
+		return true
+	}
+	for _, public := range publicPaths {
+		if path == public || strings.HasPrefix(path, public+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyHeader é o cabeçalho das chaves de API, a alternativa ao token JWT para integrações.
+const APIKeyHeader = "X-API-Key"
+
+// ErrInvalidAPIKey indica uma chave de API inexistente, revogada ou expirada.
+var ErrInvalidAPIKey = errors.New("chave de API inválida")
+
+// APIKeyAuthenticator identifica o usuário de uma chave de API, ou retorna ErrInvalidAPIKey.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*Principal, error)
+}
+
+// Middleware exige, nas rotas não públicas, uma chave de API em X-API-Key ou um token válido em
+// Authorization: Bearer, e identifica o usuário autenticado no contexto da requisição
+// (FromContext), inclusive como ator da auditoria. Sem credencial, ou com credencial inválida,
+// responde 401.
+func Middleware(verifier *Verifier, apiKeys APIKeyAuthenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isPublic(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			logger := logging.FromContext(r.Context())
+
+			if key := r.Header.Get(APIKeyHeader); key != "" {
+				principal, err := apiKeys.AuthenticateAPIKey(r.Context(), key)
+				if errors.Is(err, ErrInvalidAPIKey) {
+					logger.WithField("path", r.URL.Path).Warn("Rejected API key")
+					unauthorized(w, r, logger, "", "auth.invalid_api_key")
+					return
+				}
+				if err != nil {
+					logger.WithError(err).Error("Failed to authenticate API key")
+					writeProblem(w, logger, problem.New(problem.InternalError, r, i18n.T(i18n.FromRequest(r), "auth.authentication_failed")))
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(withPrincipal(r.Context(), principal)))
+				return
+			}
+
+			token, ok := bearerToken(r)
+			if !ok {
+				unauthorized(w, r, logger, "", "auth.missing_token")
+				return
+			}
+			principal, err := verifier.Verify(token)
+			if err != nil {
+				logger.WithError(err).WithField("path", r.URL.Path).Warn("Rejected bearer token")
+				key := "auth.invalid_token"
+				if errors.Is(err, ErrExpiredToken) {
+					key = "auth.expired_token"
+				}
+				unauthorized(w, r, logger, "invalid_token", key)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(withPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// withPrincipal identifica o usuário autenticado no contexto, também como ator da auditoria e
+// nos logs da requisição.
+func withPrincipal(ctx context.Context, principal *Principal) context.Context {
+	logging.AddFields(ctx, logrus.Fields{"principal": principal.Subject})
+	return actor.WithActor(WithPrincipal(ctx, principal), principal.Subject)
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// unauthorized responde 401 com o desafio WWW-Authenticate (RFC 6750); errorCode é omitido
+// quando a requisição não trouxe token.
+func unauthorized(w http.ResponseWriter, r *http.Request, logger *logrus.Entry, errorCode, detailKey string) {
+	challenge := `Bearer realm="dev-cloud-challenge"`
+	if errorCode != "" {
+		challenge += `, error="` + errorCode + `"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+
+	writeProblem(w, logger, problem.New(problem.Unauthorized, r, i18n.T(i18n.FromRequest(r), detailKey)))
+}
+
+func writeProblem(w http.ResponseWriter, logger *logrus.Entry, p *problem.Problem) {
+	if err := problem.Write(w, p); err != nil {
+		logger.WithError(err).Error("Failed to encode problem response")
+	}
+}
@@ -0,0 +1,30 @@
+package auth
+
+import "testing"
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/health", true},
+		{"/metrics", true},
+		{"/problems", true},
+		{"/problems/not-found", true},
+		{"/swagger/index.html", true},
+		{"/swagger/doc.json", true},
+		{"/healthz-admin", false},
+		{"/health-check", false},
+		{"/metricsX", false},
+		{"/problemsX/secreto", false},
+		{"/swaggerX", false},
+		{"/alunos", false},
+		{"/admin/health", false},
+	}
+	for _, tt := range tests {
+		if got := isPublic(tt.path); got != tt.want {
+			t.Errorf("isPublic(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package auth autentica as requisições por tokens JWT (HS256 ou RS256) assinados com as
+// chaves configuradas e disponibiliza o usuário autenticado no contexto da requisição.
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Principal é o usuário autenticado da requisição, obtido das claims do token. ProfessorID e
+// AlunoIDs vinculam o usuário ao cadastro: o professor que ele é (claim professor_id) e os
+// alunos pelos quais é responsável (claim aluno_ids). As chaves de API não têm papéis, e sim
+// Scopes.
+type Principal struct {
+	Subject     string
+	Name        string
+	Roles       []string
+	ProfessorID *int
+	AlunoIDs    []int
+	Scopes      []string
+}
+
+func (p *Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type contextKey struct{}
+
+// WithPrincipal retorna uma cópia de ctx com o usuário autenticado.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext retorna o usuário autenticado da requisição, se houver.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok
+}
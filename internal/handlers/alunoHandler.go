@@ -2,95 +2,254 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/gorilla/mux"
 	logrus "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/felipemacedo1/dev-cloud-challenge/internal/handlers")
+
 type AlunoHandler struct {
+	baseHandler
 	service services.AlunoService
-	logger  *logrus.Logger
 }
 
 func NewAlunoHandler(service services.AlunoService, logger *logrus.Logger) *AlunoHandler {
-	return &AlunoHandler{service, logger}
+	return &AlunoHandler{baseHandler{logger}, service}
 }
 
-// function to send standardized JSON responses
-func (h *AlunoHandler) sendResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if data != nil {
-		if err := json.NewEncoder(w).Encode(data); err != nil {
-			h.logger.WithError(err).Error("Failed to encode response data")
-		}
+// GetAlunos retorna os alunos cadastrados de forma paginada
+// @Summary Retorna a lista paginada de alunos
+// @Description Obtém uma página de alunos, com filtros, ordenação e paginação por offset ou cursor
+// @Tags Alunos
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param limit query int false "Quantidade máxima de alunos na página (padrão 50, máximo 500)"
+// @Param offset query int false "Quantidade de alunos a pular (não pode ser usado com cursor)"
+// @Param cursor query string false "Cursor retornado em meta.next_cursor da página anterior"
+// @Param sort query string false "Campo de ordenação; prefixe com - para ordem decrescente (ex.: -idade)"
+// @Param professor_id query int false "Filtra pelo ID do professor"
+// @Param nome_professor query string false "Filtra pelo nome do professor (ignora acentos, maiúsculas e título)"
+// @Param numero_sala query int false "Filtra pelo número da sala"
+// @Param idade_min query int false "Idade mínima"
+// @Param idade_max query int false "Idade máxima"
+// @Param situacao query string false "Filtra pela situação do aluno" Enums(aprovado, recuperacao, reprovado)
+// @Param media_min query number false "Média mínima das notas do aluno"
+// @Param media_max query number false "Média máxima das notas do aluno"
+// @Param include_deleted query bool false "Inclui os alunos removidos que ainda não foram expurgados (apenas admin)"
+// @Param as_of query string false "Lista os alunos como estavam no instante informado: data e hora RFC 3339 (ex.: 2026-03-01T00:00:00Z) ou data (AAAA-MM-DD, à meia-noite UTC)"
+// @Success 200 {object} models.AlunoPage
+// @Failure 400 {object} problem.Problem "Parâmetros de consulta inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos [get]
+func (h *AlunoHandler) GetAlunos(w http.ResponseWriter, r *http.Request) {
+	query, err := parseAlunoQuery(r.URL.Query())
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
+		return
+	}
+
+	h.log(r).WithField("query", logging.Sensitive(r.URL.RawQuery)).Info("Received request to list students")
+	page, err := h.service.ListAlunos(r.Context(), query)
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to list students")
+		h.sendError(w, r, err, "error.list_alunos")
+		return
+	}
+
+	h.log(r).WithField("total", page.Meta.Total).Info("Successfully listed students")
+	for i := range page.Data {
+		setSituacaoLabel(r, &page.Data[i])
 	}
+	// Span próprio para separar, no rastro, o tempo de codificação da página do tempo das consultas
+	_, span := tracer.Start(r.Context(), "encode response", trace.WithAttributes(attribute.Int("alunos", len(page.Data))))
+	h.sendResponse(w, r, http.StatusOK, page)
+	span.End()
 }
 
-// helper function to send JSON error responses
-func (h *AlunoHandler) sendErrorResponse(w http.ResponseWriter, statusCode int, message string) {
-	h.sendResponse(w, statusCode, models.ErrorResponse{Message: message, Code: statusCode})
+// SearchAlunos busca alunos por nome ou nome do professor
+// @Summary Busca alunos por texto
+// @Description Busca alunos pelo nome ou pelo nome do professor, ignorando acentos e maiúsculas, ordenados por relevância
+// @Tags Alunos
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param q query string true "Termo de busca"
+// @Param limit query int false "Quantidade máxima de resultados (padrão 50, máximo 500)"
+// @Success 200 {array} models.AlunoSearchResult
+// @Failure 400 {object} problem.Problem "Termo de busca inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/search [get]
+func (h *AlunoHandler) SearchAlunos(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseIntParam(r.URL.Query(), "limit")
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
+		return
+	}
+
+	term := r.URL.Query().Get("q")
+	h.log(r).WithField("q", logging.Sensitive(term)).Info("Received request to search students")
+	results, err := h.service.SearchAlunos(r.Context(), term, limit)
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to search students")
+		h.sendError(w, r, err, "error.search_alunos")
+		return
+	}
+
+	h.log(r).WithField("results", len(results)).Info("Successfully searched students")
+	for i := range results {
+		setSituacaoLabel(r, &results[i].Aluno)
+	}
+	h.sendResponse(w, r, http.StatusOK, results)
 }
 
-// GetAlunos retorna todos os alunos cadastrados
-// @Summary Retorna a lista de alunos
-// @Description Obtém a lista de TODOS os alunos cadastrados no sistema
+// GetChanges retorna as alterações de alunos desde um token de sincronização
+// @Summary Sincronização incremental de alunos
+// @Description Retorna os alunos criados, alterados e removidos (inclusive os expurgados) desde o token since, na ordem em que as alterações foram confirmadas, com o estado atual de cada aluno. Sem since, retorna todos os alunos como criados. Enquanto has_more for verdadeiro, peça a próxima página com next_token; ao final, guarde next_token para a próxima sincronização. Tokens de versões anteriores são rejeitados: recomece pela sincronização inicial
 // @Tags Alunos
 // @Accept  json
 // @Produce  json
-// @Success 200 {array} models.Aluno
-// @Failure 500 {object} models.ErrorResponse "Erro interno no servidor"
-// @Router /alunos [get]
-func (h *AlunoHandler) GetAlunos(w http.ResponseWriter, r *http.Request) {
-	h.logger.Info("Received request to get all students")
-	alunos, err := h.service.GetAllAlunos()
+// @Produce  application/problem+json
+// @Param since query string false "Token next_token da sincronização anterior"
+// @Param limit query int false "Quantidade máxima de alterações (padrão 50, máximo 500)"
+// @Success 200 {object} models.AlunoChanges
+// @Failure 400 {object} problem.Problem "Token ou parâmetros inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/changes [get]
+func (h *AlunoHandler) GetChanges(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseIntParam(r.URL.Query(), "limit")
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to get all students")
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erro ao obter alunos")
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
 		return
 	}
 
-	h.logger.Info("Successfully retrieved all students")
-	h.sendResponse(w, http.StatusOK, alunos)
+	since := r.URL.Query().Get("since")
+	h.log(r).WithField("since", since).Info("Received request to list student changes")
+	changes, err := h.service.ListChanges(r.Context(), since, limit)
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to list student changes")
+		h.sendError(w, r, err, "error.list_changes")
+		return
+	}
+
+	h.log(r).WithFields(logrus.Fields{
+		"created": len(changes.Created),
+		"updated": len(changes.Updated),
+		"deleted": len(changes.Deleted),
+	}).Info("Successfully listed student changes")
+	for i := range changes.Created {
+		setSituacaoLabel(r, &changes.Created[i])
+	}
+	for i := range changes.Updated {
+		setSituacaoLabel(r, &changes.Updated[i])
+	}
+	h.sendResponse(w, r, http.StatusOK, changes)
 }
 
 // GetAluno retorna um aluno específico
 // @Summary Retorna um aluno pelo ID
-// @Description Obtém os dados de um aluno específico pelo ID
+// @Description Obtém os dados de um aluno específico pelo ID. O ETag da resposta identifica a versão do aluno e deve ser enviado em If-Match nas alterações; com If-None-Match, responde 304 se o aluno não mudou. Com as_of, a resposta não tem ETag e o aluno não é encontrado antes da sua criação ou do início do histórico de versões
 // @Tags Alunos
 // @Accept  json
 // @Produce  json
+// @Produce  application/problem+json
 // @Param id path int true "ID do Aluno"
+// @Param include_deleted query bool false "Retorna o aluno mesmo que ele tenha sido removido (apenas admin)"
+// @Param as_of query string false "Retorna o aluno como estava no instante informado: data e hora RFC 3339 (ex.: 2026-03-01T00:00:00Z) ou data (AAAA-MM-DD, à meia-noite UTC)"
+// @Param If-None-Match header string false "ETag da versão que o cliente já tem"
 // @Success 200 {object} models.Aluno "Dados do Aluno"
-// @Failure 400 {object} models.ErrorResponse "ID inválido"
-// @Failure 404 {object} models.ErrorResponse "Aluno não encontrado"
-// @Failure 500 {object} models.ErrorResponse "Erro interno no servidor"
+// @Header 200 {string} ETag "Versão do aluno (ausente com as_of)"
+// @Success 304 "Aluno não alterado"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /alunos/{id} [get]
 func (h *AlunoHandler) GetAluno(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		h.logger.WithField("id", vars["id"]).Error("Invalid ID format")
-		h.sendErrorResponse(w, http.StatusBadRequest, "ID inválido")
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
 		return
 	}
 
-	h.logger.WithField("id", id).Info("Received request to get a student by ID")
+	includeDeleted, err := parseBoolParam(r.URL.Query(), "include_deleted")
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
+		return
+	}
+	asOf, err := parseOptionalTime(r.URL.Query(), "as_of", false)
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
+		return
+	}
 
-	aluno, err := h.service.GetAlunoByID(id)
+	h.log(r).WithField("id", id).Info("Received request to get a student by ID")
+
+	var aluno *models.Aluno
+	switch {
+	case asOf != nil:
+		aluno, err = h.service.GetAlunoAsOf(r.Context(), id, *asOf, includeDeleted)
+	case includeDeleted:
+		aluno, err = h.service.GetAlunoIncludingDeleted(r.Context(), id)
+	default:
+		aluno, err = h.service.GetAlunoByID(r.Context(), id)
+	}
 	if err != nil {
-		h.logger.WithField("id", id).WithError(err).Error("Failed to get student by ID")
-		h.sendErrorResponse(w, http.StatusNotFound, "Aluno não encontrado")
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to get student by ID")
+		h.sendError(w, r, err, "error.get_aluno")
 		return
 	}
 
-	h.logger.WithField("id", id).Info("Successfully retrieved student by ID")
-	h.sendResponse(w, http.StatusOK, aluno)
+	// O ETag identifica a versão atual; as versões antigas (as_of) não são alteráveis nem revalidadas
+	if asOf == nil {
+		setAlunoETag(w, aluno)
+		if noneMatch(r, alunoETag(aluno)) {
+			h.log(r).WithField("id", id).Info("Student not modified")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully retrieved student by ID")
+	setSituacaoLabel(r, aluno)
+	h.sendResponse(w, r, http.StatusOK, aluno)
 }
 
 // CreateAluno cria um novo aluno
@@ -99,101 +258,296 @@ func (h *AlunoHandler) GetAluno(w http.ResponseWriter, r *http.Request) {
 // @Tags Alunos
 // @Accept  json
 // @Produce  json
+// @Produce  application/problem+json
 // @Param aluno body models.Aluno true "Dados do Aluno"
 // @Success 201 {object} models.Aluno
-// @Failure 400 {object} models.ErrorResponse "Dados do aluno inválidos"
-// @Failure 500 {object} models.ErrorResponse "Erro interno no servidor"
+// @Header 201 {string} ETag "Versão do aluno"
+// @Failure 400 {object} problem.Problem "JSON inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 409 {object} problem.Problem "Sala sem vagas"
+// @Failure 422 {object} problem.Problem "Nome, idade, professor ou sala inválidos"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /alunos [post]
 func (h *AlunoHandler) CreateAluno(w http.ResponseWriter, r *http.Request) {
 	var aluno models.Aluno
 	if err := json.NewDecoder(r.Body).Decode(&aluno); err != nil {
-		h.logger.WithError(err).Error("Failed to decode student data")
-		h.sendErrorResponse(w, http.StatusBadRequest, "Dados do aluno inválidos")
+		h.log(r).WithError(err).Error("Failed to decode student data")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_aluno")
 		return
 	}
 
-	h.logger.WithField("student", aluno).Info("Received request to create a new student")
+	h.log(r).WithField("student", aluno).Info("Received request to create a new student")
 
-	if err := h.service.CreateAluno(&aluno); err != nil {
-		h.logger.WithError(err).Error("Failed to create a new student")
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erro ao criar aluno")
+	if err := h.service.CreateAluno(r.Context(), &aluno); err != nil {
+		h.log(r).WithError(err).Error("Failed to create a new student")
+		h.sendError(w, r, err, "error.create_aluno")
 		return
 	}
 
-	h.logger.WithField("student", aluno).Info("Successfully created a new student")
-	h.sendResponse(w, http.StatusCreated, aluno)
+	h.log(r).WithField("student", aluno).Info("Successfully created a new student")
+	setAlunoETag(w, &aluno)
+	setSituacaoLabel(r, &aluno)
+	h.sendResponse(w, r, http.StatusCreated, aluno)
 }
 
 // UpdateAluno atualiza os dados de um aluno
 // @Summary Atualiza os dados de um aluno
-// @Description Atualiza as informações de um aluno específico pelo ID
+// @Description Atualiza as informações de um aluno específico pelo ID, se ele ainda estiver na versão informada em If-Match
 // @Tags Alunos
 // @Accept  json
 // @Produce  json
+// @Produce  application/problem+json
 // @Param id path int true "ID do Aluno"
+// @Param If-Match header string true "ETag da versão do aluno que está sendo alterada, ou *"
 // @Param aluno body models.Aluno true "Dados do Aluno"
 // @Success 200 {object} models.Aluno
-// @Failure 400 {object} models.ErrorResponse "Dados inválidos ou ID inválido"
-// @Failure 404 {object} models.ErrorResponse "Aluno não encontrado"
-// @Failure 500 {object} models.ErrorResponse "Erro interno no servidor"
+// @Header 200 {string} ETag "Nova versão do aluno"
+// @Failure 400 {object} problem.Problem "JSON inválido ou ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado"
+// @Failure 409 {object} problem.Problem "Sala sem vagas"
+// @Failure 412 {object} problem.Problem "O aluno foi alterado desde a versão informada"
+// @Failure 422 {object} problem.Problem "Nome, idade, professor ou sala inválidos"
+// @Failure 428 {object} problem.Problem "If-Match ausente"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /alunos/{id} [put]
 func (h *AlunoHandler) UpdateAluno(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		h.logger.WithField("id", vars["id"]).Error("Invalid ID format")
-		h.sendErrorResponse(w, http.StatusBadRequest, "ID inválido")
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	version, ok := h.ifMatchVersion(w, r)
+	if !ok {
 		return
 	}
 
 	var aluno models.Aluno
 	if err := json.NewDecoder(r.Body).Decode(&aluno); err != nil {
-		h.logger.WithError(err).Error("Failed to decode student data for update")
-		h.sendErrorResponse(w, http.StatusBadRequest, "Dados inválidos do aluno")
+		h.log(r).WithError(err).Error("Failed to decode student data for update")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_aluno")
+		return
+	}
+	aluno.ID, aluno.Version = id, version
+
+	h.log(r).WithField("student", aluno).Info("Received request to update student")
+
+	if err := h.service.UpdateAluno(r.Context(), &aluno); err != nil {
+		h.log(r).WithError(err).Error("Failed to update student")
+		h.sendError(w, r, err, "error.update_aluno")
+		return
+	}
+
+	h.log(r).WithField("student", aluno).Info("Successfully updated student")
+	setAlunoETag(w, &aluno)
+	setSituacaoLabel(r, &aluno)
+	h.sendResponse(w, r, http.StatusOK, aluno)
+}
+
+// PatchAluno atualiza parcialmente os dados de um aluno
+// @Summary Atualiza parcialmente um aluno
+// @Description Altera apenas os campos informados, usando JSON Merge Patch (RFC 7396, Content-Type application/merge-patch+json) ou JSON Patch (RFC 6902, Content-Type application/json-patch+json), se o aluno ainda estiver na versão informada em If-Match
+// @Tags Alunos
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Accept  application/json-patch+json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Aluno"
+// @Param If-Match header string true "ETag da versão do aluno que está sendo alterada, ou *"
+// @Param patch body object true "Merge patch ou lista de operações JSON Patch"
+// @Success 200 {object} models.Aluno
+// @Header 200 {string} ETag "Nova versão do aluno"
+// @Failure 400 {object} problem.Problem "Patch inválido ou ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado"
+// @Failure 409 {object} problem.Problem "Operação test falhou ou sala sem vagas"
+// @Failure 412 {object} problem.Problem "O aluno foi alterado desde a versão informada"
+// @Failure 415 {object} problem.Problem "Tipo de patch não suportado"
+// @Failure 422 {object} problem.Problem "Nome, idade, professor ou sala inválidos"
+// @Failure 428 {object} problem.Problem "If-Match ausente"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/{id} [patch]
+func (h *AlunoHandler) PatchAluno(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	version, ok := h.ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to read patch body")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_patch")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to patch student")
+
+	current, err := h.service.GetAlunoByID(r.Context(), id)
+	if err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to get student by ID")
+		h.sendError(w, r, err, "error.update_aluno")
+		return
+	}
+
+	original, err := json.Marshal(current)
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to encode student for patch")
+		h.sendProblem(w, r, problem.InternalError, "error.update_aluno")
+		return
+	}
+
+	patched, err := applyPatch(r.Header.Get("Content-Type"), original, body)
+	switch {
+	case errors.Is(err, errUnsupportedPatchType):
+		h.log(r).WithField("content_type", r.Header.Get("Content-Type")).Error("Unsupported patch media type")
+		h.sendProblem(w, r, problem.UnsupportedMediaType, "request.unsupported_patch_type")
+		return
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		h.log(r).WithError(err).Error("JSON Patch test operation failed")
+		h.sendProblem(w, r, problem.Conflict, "request.patch_test_failed")
+		return
+	case err != nil:
+		h.log(r).WithError(err).Error("Failed to apply patch")
+		h.writeProblem(w, r, problem.New(problem.InvalidRequest, r, errorMessage(err, i18n.FromRequest(r))))
 		return
 	}
-	aluno.ID = id
 
-	h.logger.WithField("student", aluno).Info("Received request to update student")
+	patch, err := diffAlunoPatch(original, patched)
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid patch")
+		h.writeProblem(w, r, problem.New(problem.InvalidRequest, r, errorMessage(err, i18n.FromRequest(r))))
+		return
+	}
 
-	if err := h.service.UpdateAluno(&aluno); err != nil {
-		h.logger.WithError(err).Error("Failed to update student")
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erro ao atualizar aluno")
+	aluno, err := h.service.PatchAluno(r.Context(), id, version, patch)
+	if err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to patch student")
+		h.sendError(w, r, err, "error.update_aluno")
 		return
 	}
 
-	h.logger.WithField("student", aluno).Info("Successfully updated student")
-	h.sendResponse(w, http.StatusOK, aluno)
+	h.log(r).WithField("student", aluno).Info("Successfully patched student")
+	setAlunoETag(w, aluno)
+	setSituacaoLabel(r, aluno)
+	h.sendResponse(w, r, http.StatusOK, aluno)
 }
 
 // DeleteAluno deleta um aluno
 // @Summary Deleta um aluno pelo ID
-// @Description Remove um aluno específico pelo ID
+// @Description Remove um aluno específico pelo ID, se ele ainda estiver na versão informada em If-Match. O aluno removido pode ser restaurado até ser expurgado, após o período de retenção
 // @Tags Alunos
 // @Accept  json
 // @Produce  json
+// @Produce  application/problem+json
 // @Param id path int true "ID do Aluno"
+// @Param If-Match header string true "ETag da versão do aluno que está sendo removida, ou *"
 // @Success 204 "No Content"
-// @Failure 404 {object} models.ErrorResponse "Aluno não encontrado"
-// @Failure 500 {object} models.ErrorResponse "Erro interno no servidor"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado"
+// @Failure 412 {object} problem.Problem "O aluno foi alterado desde a versão informada"
+// @Failure 428 {object} problem.Problem "If-Match ausente"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /alunos/{id} [delete]
 func (h *AlunoHandler) DeleteAluno(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		h.logger.WithField("id", vars["id"]).Error("Invalid ID format")
-		h.sendErrorResponse(w, http.StatusBadRequest, "ID inválido")
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
 		return
 	}
 
-	h.logger.WithField("id", id).Info("Received request to delete student")
+	version, ok := h.ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
 
-	if err := h.service.DeleteAluno(id); err != nil {
-		h.logger.WithField("id", id).WithError(err).Error("Failed to delete student")
-		h.sendErrorResponse(w, http.StatusNotFound, "Aluno não encontrado")
+	h.log(r).WithField("id", id).Info("Received request to delete student")
+
+	if err := h.service.DeleteAluno(r.Context(), id, version); err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to delete student")
+		h.sendError(w, r, err, "error.delete_aluno")
 		return
 	}
 
-	h.logger.WithField("id", id).Info("Successfully deleted student")
+	h.log(r).WithField("id", id).Info("Successfully deleted student")
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// setSituacaoLabel preenche o nome da situação do aluno no idioma da requisição.
+func setSituacaoLabel(r *http.Request, aluno *models.Aluno) {
+	if aluno.Situacao == nil {
+		aluno.SituacaoLabel = nil
+		return
+	}
+	label := i18n.T(i18n.FromRequest(r), "situacao."+*aluno.Situacao)
+	aluno.SituacaoLabel = &label
+}
+
+// RestoreAluno restaura um aluno removido
+// @Summary Restaura um aluno removido
+// @Description Desfaz a remoção de um aluno que ainda não foi expurgado. Restaurar um aluno que não foi removido não altera nada
+// @Tags Alunos
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Aluno"
+// @Success 200 {object} models.Aluno
+// @Header 200 {string} ETag "Nova versão do aluno"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado ou já expurgado"
+// @Failure 409 {object} problem.Problem "Sala sem vagas"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/{id}/restore [post]
+func (h *AlunoHandler) RestoreAluno(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to restore student")
+
+	aluno, err := h.service.RestoreAluno(r.Context(), id)
+	if err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to restore student")
+		h.sendError(w, r, err, "error.restore_aluno")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully restored student")
+	setAlunoETag(w, aluno)
+	setSituacaoLabel(r, aluno)
+	h.sendResponse(w, r, http.StatusOK, aluno)
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// alunoServiceStub retorna o aluno 1 na versão 3 como versão atual e na versão 2 como versão
+// antiga; os demais métodos não são usados nos testes.
+type alunoServiceStub struct {
+	services.AlunoService
+}
+
+func (alunoServiceStub) GetAlunoByID(ctx context.Context, id int) (*models.Aluno, error) {
+	return &models.Aluno{ID: id, Nome: "Ana", Version: 3}, nil
+}
+
+func (alunoServiceStub) GetAlunoAsOf(ctx context.Context, id int, asOf time.Time, includeDeleted bool) (*models.Aluno, error) {
+	return &models.Aluno{ID: id, Nome: "Ana", Version: 2}, nil
+}
+
+func TestGetAlunoETag(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		ifNoneMatch string
+		wantStatus  int
+		wantETag    string
+	}{
+		{name: "versão atual", target: "/alunos/1", wantStatus: http.StatusOK, wantETag: `"3"`},
+		{name: "versão atual não alterada", target: "/alunos/1", ifNoneMatch: `"3"`, wantStatus: http.StatusNotModified, wantETag: `"3"`},
+		{name: "as_of sem ETag", target: "/alunos/1?as_of=2024-01-01", wantStatus: http.StatusOK},
+		{name: "as_of ignora If-None-Match", target: "/alunos/1?as_of=2024-01-01", ifNoneMatch: `"2"`, wantStatus: http.StatusOK},
+		{name: "as_of ignora If-None-Match curinga", target: "/alunos/1?as_of=2024-01-01", ifNoneMatch: "*", wantStatus: http.StatusOK},
+	}
+	h := NewAlunoHandler(alunoServiceStub{}, logrus.New())
+	router := mux.NewRouter()
+	router.HandleFunc("/alunos/{id}", h.GetAluno)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if etag := rec.Header().Get("ETag"); etag != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", etag, tt.wantETag)
+			}
+		})
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int
+		wantErr error
+	}{
+		{name: "ETag forte", header: `"3"`, want: 3},
+		{name: "ETag com espaços", header: ` "3" `, want: 3},
+		{name: "curinga", header: "*", want: models.AnyVersion},
+		{name: "ausente", header: "", wantErr: errIfMatchMissing},
+		{name: "só espaços", header: "  ", wantErr: errIfMatchMissing},
+		{name: "ETag fraco", header: `W/"3"`, wantErr: errIfMatchInvalid},
+		{name: "lista de ETags", header: `"2", "3"`, wantErr: errIfMatchInvalid},
+		{name: "sem aspas", header: "3", wantErr: errIfMatchInvalid},
+		{name: "sem aspas finais", header: `"3`, wantErr: errIfMatchInvalid},
+		{name: "não numérico", header: `"abc"`, wantErr: errIfMatchInvalid},
+		{name: "versão zero", header: `"0"`, wantErr: errIfMatchInvalid},
+		{name: "versão negativa", header: `"-1"`, wantErr: errIfMatchInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIfMatch(tt.header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseIfMatch(%q) error = %v, want %v", tt.header, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseIfMatch(%q) = %d, want %d", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		wantOK      bool
+		wantVersion int
+		wantStatus  int
+	}{
+		{name: "ETag forte", ifMatch: `"3"`, wantOK: true, wantVersion: 3, wantStatus: http.StatusOK},
+		{name: "curinga", ifMatch: "*", wantOK: true, wantVersion: models.AnyVersion, wantStatus: http.StatusOK},
+		{name: "ausente", wantStatus: http.StatusPreconditionRequired},
+		{name: "ETag fraco", ifMatch: `W/"3"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "lista de ETags", ifMatch: `"2", "3"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "sem aspas", ifMatch: "3", wantStatus: http.StatusPreconditionFailed},
+	}
+	h := NewAlunoHandler(alunoServiceStub{}, logrus.New())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/alunos/1", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			version, ok := h.ifMatchVersion(rec, req)
+			if ok != tt.wantOK || version != tt.wantVersion {
+				t.Errorf("ifMatchVersion = %d, %v, want %d, %v", version, ok, tt.wantVersion, tt.wantOK)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !tt.wantOK && rec.Header().Get("Content-Type") != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"mime"
+	"reflect"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	errInvalidPatch         = errors.New("patch inválido")
+	errUnsupportedPatchType = errors.New("tipo de patch não suportado")
+)
+
+// patchError é um errInvalidPatch com o motivo descrito por uma mensagem do catálogo.
+type patchError struct {
+	message i18n.Message
+}
+
+func invalidPatch(key string, args ...interface{}) error {
+	return &patchError{i18n.NewMessage(key, args...)}
+}
+
+func (e *patchError) Error() string {
+	return errInvalidPatch.Error() + ": " + e.message.In(i18n.Default)
+}
+
+func (e *patchError) Localize(lang i18n.Lang) string { return e.message.In(lang) }
+
+func (e *patchError) Unwrap() error { return errInvalidPatch }
+
+// applyPatch aplica o corpo de um PATCH ao documento JSON original: JSON Patch (RFC 6902) quando
+// o Content-Type é application/json-patch+json e JSON Merge Patch (RFC 7396) caso contrário.
+func applyPatch(contentType string, original, body []byte) ([]byte, error) {
+	mediaType := ""
+	if contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return nil, errUnsupportedPatchType
+		}
+	}
+
+	switch mediaType {
+	case jsonPatchContentType:
+		patch, err := jsonpatch.DecodePatch(body)
+		if err != nil {
+			return nil, invalidPatch("patch.malformed", err.Error())
+		}
+		patched, err := patch.Apply(original)
+		if err != nil && !errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, invalidPatch("patch.malformed", err.Error())
+		}
+		return patched, err
+	case mergePatchContentType, "application/json", "":
+		patched, err := jsonpatch.MergePatch(original, body)
+		if err != nil {
+			return nil, invalidPatch("patch.malformed", err.Error())
+		}
+		return patched, nil
+	default:
+		return nil, errUnsupportedPatchType
+	}
+}
+
+// diffAlunoPatch compara o aluno antes e depois do patch e monta um models.AlunoPatch apenas com
+// os campos alterados. Alterações em campos somente leitura ou desconhecidos são rejeitadas.
+func diffAlunoPatch(original, patched []byte) (models.AlunoPatch, error) {
+	var patch models.AlunoPatch
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return patch, err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return patch, invalidPatch("patch.not_object")
+	}
+
+	keys := map[string]bool{}
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+
+	for key := range keys {
+		oldValue, hadKey := before[key]
+		newValue, hasKey := after[key]
+		if hadKey == hasKey && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		switch key {
+		case "nome":
+			v, ok := newValue.(string)
+			if !ok {
+				return patch, invalidPatch("patch.expected_string", key)
+			}
+			patch.Nome = &v
+		case "idade":
+			v, ok := intValue(newValue)
+			if !ok {
+				return patch, invalidPatch("patch.expected_int", key)
+			}
+			patch.Idade = &v
+		case "numero_sala":
+			v, ok := intValue(newValue)
+			if !ok {
+				return patch, invalidPatch("patch.expected_int", key)
+			}
+			patch.NumeroSala = &v
+		case "professor_id":
+			if newValue == nil {
+				patch.ClearProfessor = true
+				continue
+			}
+			v, ok := intValue(newValue)
+			if !ok {
+				return patch, invalidPatch("patch.expected_int_or_null", key)
+			}
+			patch.ProfessorID = &v
+		case "nome_professor":
+			v, ok := newValue.(string)
+			if !ok && newValue != nil {
+				return patch, invalidPatch("patch.expected_string_or_null", key)
+			}
+			patch.NomeProfessor = &v
+		case "id", "media", "situacao", "situacao_label", "version", "created_at", "updated_at", "deleted_at":
+			return patch, invalidPatch("patch.read_only", key)
+		default:
+			return patch, invalidPatch("patch.unknown_field", key)
+		}
+	}
+
+	// professor_id explícito prevalece sobre nome_professor
+	if patch.ProfessorID != nil || patch.ClearProfessor {
+		patch.NomeProfessor = nil
+	}
+	return patch, nil
+}
+
+func intValue(v interface{}) (int, bool) {
+	f, ok := v.(float64)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
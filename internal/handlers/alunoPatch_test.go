@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+const alunoJSON = `{"id":1,"nome":"Ana","idade":15,"professor_id":3,"nome_professor":"Prof. Silva","numero_sala":101,` +
+	`"media":7.5,"situacao":"aprovado","version":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","deleted_at":null}`
+
+func intPtr(v int) *int       { return &v }
+func strPtr(v string) *string { return &v }
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        models.AlunoPatch
+		wantErr     error
+	}{
+		{
+			name:        "merge patch",
+			contentType: "application/merge-patch+json",
+			body:        `{"nome":"Ana Maria","idade":16}`,
+			want:        models.AlunoPatch{Nome: strPtr("Ana Maria"), Idade: intPtr(16)},
+		},
+		{
+			name: "merge patch sem Content-Type",
+			body: `{"numero_sala":102}`,
+			want: models.AlunoPatch{NumeroSala: intPtr(102)},
+		},
+		{
+			name:        "merge patch com charset",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"professor_id":null}`,
+			want:        models.AlunoPatch{ClearProfessor: true},
+		},
+		{
+			name:        "json patch",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"replace","path":"/idade","value":16},{"op":"replace","path":"/nome_professor","value":"Prof. Souza"}]`,
+			want:        models.AlunoPatch{Idade: intPtr(16), NomeProfessor: strPtr("Prof. Souza")},
+		},
+		{
+			name:        "json patch com test satisfeito",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/version","value":2},{"op":"replace","path":"/nome","value":"Bia"}]`,
+			want:        models.AlunoPatch{Nome: strPtr("Bia")},
+		},
+		{
+			name:        "json patch com test falho",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/version","value":1},{"op":"replace","path":"/nome","value":"Bia"}]`,
+			wantErr:     jsonpatch.ErrTestFailed,
+		},
+		{
+			name:        "json patch malformado",
+			contentType: "application/json-patch+json",
+			body:        `{"op":"replace"}`,
+			wantErr:     errInvalidPatch,
+		},
+		{
+			name:        "json patch em caminho inexistente",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"replace","path":"/turma/0","value":1}]`,
+			wantErr:     errInvalidPatch,
+		},
+		{
+			name:        "merge patch malformado",
+			contentType: "application/merge-patch+json",
+			body:        `{"nome":`,
+			wantErr:     errInvalidPatch,
+		},
+		{name: "tipo não suportado", contentType: "text/plain", body: `nome=Bia`, wantErr: errUnsupportedPatchType},
+		{name: "Content-Type inválido", contentType: "application/", body: `{}`, wantErr: errUnsupportedPatchType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := applyPatch(tt.contentType, []byte(alunoJSON), []byte(tt.body))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("applyPatch error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyPatch: %v", err)
+			}
+			got, err := diffAlunoPatch([]byte(alunoJSON), patched)
+			if err != nil {
+				t.Fatalf("diffAlunoPatch: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("patch = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffAlunoPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		want    models.AlunoPatch
+		wantKey string
+	}{
+		{name: "sem alterações", patch: `{}`},
+		{name: "mesmo valor", patch: `{"nome":"Ana","idade":15}`},
+		{name: "idade", patch: `{"idade":16}`, want: models.AlunoPatch{Idade: intPtr(16)}},
+		{
+			name:  "professor_id prevalece sobre nome_professor",
+			patch: `{"professor_id":4,"nome_professor":"Prof. Souza"}`,
+			want:  models.AlunoPatch{ProfessorID: intPtr(4)},
+		},
+		{name: "nome_professor nulo", patch: `{"nome_professor":null}`, want: models.AlunoPatch{NomeProfessor: strPtr("")}},
+		{name: "id", patch: `{"id":2}`, wantKey: "patch.read_only"},
+		{name: "media", patch: `{"media":10}`, wantKey: "patch.read_only"},
+		{name: "situacao", patch: `{"situacao":"reprovado"}`, wantKey: "patch.read_only"},
+		{name: "version", patch: `{"version":3}`, wantKey: "patch.read_only"},
+		{name: "remoção de campo somente leitura", patch: `{"created_at":null}`, wantKey: "patch.read_only"},
+		{name: "campo desconhecido", patch: `{"turma":"A"}`, wantKey: "patch.unknown_field"},
+		{name: "nome numérico", patch: `{"nome":42}`, wantKey: "patch.expected_string"},
+		{name: "nome nulo", patch: `{"nome":null}`, wantKey: "patch.expected_string"},
+		{name: "idade fracionária", patch: `{"idade":15.5}`, wantKey: "patch.expected_int"},
+		{name: "idade textual", patch: `{"idade":"16"}`, wantKey: "patch.expected_int"},
+		{name: "numero_sala nulo", patch: `{"numero_sala":null}`, wantKey: "patch.expected_int"},
+		{name: "professor_id textual", patch: `{"professor_id":"4"}`, wantKey: "patch.expected_int_or_null"},
+		{name: "nome_professor numérico", patch: `{"nome_professor":4}`, wantKey: "patch.expected_string_or_null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := applyPatch(mergePatchContentType, []byte(alunoJSON), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("applyPatch: %v", err)
+			}
+			got, err := diffAlunoPatch([]byte(alunoJSON), patched)
+			if tt.wantKey != "" {
+				var pe *patchError
+				if !errors.As(err, &pe) || !errors.Is(err, errInvalidPatch) {
+					t.Fatalf("diffAlunoPatch error = %v, want patchError", err)
+				}
+				if pe.message.Key != tt.wantKey {
+					t.Errorf("message key = %q, want %q", pe.message.Key, tt.wantKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("diffAlunoPatch: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("patch = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffAlunoPatchNotObject(t *testing.T) {
+	_, err := diffAlunoPatch([]byte(alunoJSON), []byte(`[1, 2]`))
+	var pe *patchError
+	if !errors.As(err, &pe) || pe.message.Key != "patch.not_object" {
+		t.Errorf("diffAlunoPatch error = %v, want patch.not_object", err)
+	}
+}
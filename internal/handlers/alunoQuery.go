@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+// parseAlunoQuery converte os parâmetros da query string de GET /alunos em um models.AlunoQuery.
+func parseAlunoQuery(values url.Values) (models.AlunoQuery, error) {
+	var query models.AlunoQuery
+	var err error
+
+	if query.Limit, err = parseIntParam(values, "limit"); err != nil {
+		return query, err
+	}
+	if query.Offset, err = parseIntParam(values, "offset"); err != nil {
+		return query, err
+	}
+	if query.Limit < 0 || query.Offset < 0 {
+		return query, models.InvalidQuery("query.negative_pagination")
+	}
+	query.Cursor = values.Get("cursor")
+	if query.Cursor != "" && query.Offset > 0 {
+		return query, models.InvalidQuery("query.cursor_with_offset")
+	}
+
+	sort := values.Get("sort")
+	if strings.HasPrefix(sort, "-") {
+		query.Desc = true
+		sort = sort[1:]
+	}
+	query.Sort = sort
+
+	if query.IncludeDeleted, err = parseBoolParam(values, "include_deleted"); err != nil {
+		return query, err
+	}
+	if query.AsOf, err = parseOptionalTime(values, "as_of", false); err != nil {
+		return query, err
+	}
+
+	query.NomeProfessor = values.Get("nome_professor")
+	query.Situacao = values.Get("situacao")
+
+	intFilters := map[string]**int{
+		"professor_id": &query.ProfessorID,
+		"numero_sala":  &query.NumeroSala,
+		"idade_min":    &query.IdadeMin,
+		"idade_max":    &query.IdadeMax,
+	}
+	for name, dest := range intFilters {
+		if *dest, err = parseOptionalInt(values, name); err != nil {
+			return query, err
+		}
+	}
+
+	floatFilters := map[string]**float64{
+		"media_min": &query.MediaMin,
+		"media_max": &query.MediaMax,
+	}
+	for name, dest := range floatFilters {
+		if *dest, err = parseOptionalFloat(values, name); err != nil {
+			return query, err
+		}
+	}
+
+	return query, nil
+}
+
+func parseIntParam(values url.Values, name string) (int, error) {
+	v, err := parseOptionalInt(values, name)
+	if err != nil || v == nil {
+		return 0, err
+	}
+	return *v, nil
+}
+
+func parseOptionalInt(values url.Values, name string) (*int, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, models.InvalidQuery("query.int_param", name)
+	}
+	return &v, nil
+}
+
+func parseBoolParam(values url.Values, name string) (bool, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, models.InvalidQuery("query.bool_param", name)
+	}
+	return v, nil
+}
+
+func parseOptionalFloat(values url.Values, name string) (*float64, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, models.InvalidQuery("query.number_param", name)
+	}
+	return &v, nil
+}
+
+// parseOptionalTime aceita data e hora RFC 3339 ou apenas a data. Como limite final (endOfDay),
+// a data inclui o dia inteiro: o limite passa a ser o início do dia seguinte.
+func parseOptionalTime(values url.Values, name string, endOfDay bool) (*time.Time, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, models.InvalidQuery("query.time_param", name)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// APIKeyHandler administra as chaves de API das integrações.
+type APIKeyHandler struct {
+	baseHandler
+	service services.APIKeyService
+}
+
+func NewAPIKeyHandler(service services.APIKeyService, logger *logrus.Logger) *APIKeyHandler {
+	return &APIKeyHandler{baseHandler{logger}, service}
+}
+
+// GetAPIKeys lista as chaves de API
+// @Summary Lista as chaves de API
+// @Description Retorna todas as chaves de API, inclusive as revogadas e expiradas, sem o valor das chaves. Apenas admin.
+// @Tags Administração
+// @Produce  json
+// @Produce  application/problem+json
+// @Success 200 {array} models.APIKey
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	h.log(r).Info("Received request to list API keys")
+
+	keys, err := h.service.ListAPIKeys(r.Context())
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to list API keys")
+		h.sendError(w, r, err, "error.list_api_keys")
+		return
+	}
+
+	h.log(r).WithField("total", len(keys)).Info("Successfully listed API keys")
+	h.sendResponse(w, r, http.StatusOK, keys)
+}
+
+// CreateAPIKey cria uma chave de API
+// @Summary Cria uma chave de API
+// @Description Gera uma chave de API com os escopos informados (alunos:read, alunos:write) e, opcionalmente, uma data de expiração. O valor da chave (key) só é retornado nesta resposta; envie-o no cabeçalho X-API-Key. Apenas admin.
+// @Tags Administração
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param apiKey body models.NovaAPIKey true "Dados da chave"
+// @Success 201 {object} models.APIKeyCriada
+// @Failure 400 {object} problem.Problem "Dados da chave inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 422 {object} problem.Problem "Nome, escopos ou expiração inválidos"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var nova models.NovaAPIKey
+	if err := json.NewDecoder(r.Body).Decode(&nova); err != nil {
+		h.log(r).WithError(err).Error("Failed to decode API key data")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_api_key")
+		return
+	}
+
+	h.log(r).WithField("nome", nova.Nome).Info("Received request to create an API key")
+
+	created, err := h.service.CreateAPIKey(r.Context(), nova)
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to create API key")
+		h.sendError(w, r, err, "error.create_api_key")
+		return
+	}
+
+	h.log(r).WithField("id", created.ID).Info("Successfully created API key")
+	h.sendResponse(w, r, http.StatusCreated, created)
+}
+
+// RevokeAPIKey revoga uma chave de API
+// @Summary Revoga uma chave de API
+// @Description Revoga a chave, que deixa de autenticar imediatamente. A chave continua na listagem, com revoked_at preenchido. Apenas admin.
+// @Tags Administração
+// @Produce  application/problem+json
+// @Param id path int true "ID da chave"
+// @Success 204 "No Content"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Chave não encontrada"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to revoke API key")
+
+	if err := h.service.RevokeAPIKey(r.Context(), id); err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to revoke API key")
+		h.sendError(w, r, err, "error.revoke_api_key")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully revoked API key")
+	w.WriteHeader(http.StatusNoContent)
+}
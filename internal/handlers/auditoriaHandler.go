@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+type AuditoriaHandler struct {
+	baseHandler
+	service services.AuditoriaService
+}
+
+func NewAuditoriaHandler(service services.AuditoriaService, logger *logrus.Logger) *AuditoriaHandler {
+	return &AuditoriaHandler{baseHandler{logger}, service}
+}
+
+// GetAuditoria retorna a trilha de auditoria dos alunos
+// @Summary Consulta a trilha de auditoria
+// @Description Retorna as criações, alterações e remoções de alunos, da mais recente para a mais antiga, com o ator, a operação e os campos alterados (antes e depois). Apenas os dados dos alunos são auditados: notas (exceto pelo efeito na média e na situação), professores, salas e disciplinas não entram na trilha
+// @Tags Auditoria
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param aluno_id query int false "Filtra pelo ID do aluno"
+// @Param ator query string false "Filtra pelo ator"
+// @Param operacao query string false "Filtra pela operação" Enums(criacao, alteracao, situacao, remocao, restauracao, expurgo)
+// @Param desde query string false "Data (AAAA-MM-DD) ou data e hora (RFC 3339) inicial, inclusive"
+// @Param ate query string false "Data (AAAA-MM-DD, inclusive) ou data e hora (RFC 3339, exclusive) final"
+// @Param limit query int false "Quantidade máxima de entradas na página (padrão 50, máximo 500)"
+// @Param offset query int false "Quantidade de entradas a pular"
+// @Success 200 {object} models.AuditoriaPage
+// @Failure 400 {object} problem.Problem "Parâmetros de consulta inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Router /auditoria [get]
+func (h *AuditoriaHandler) GetAuditoria(w http.ResponseWriter, r *http.Request) {
+	query, err := parseAuditoriaQuery(r.URL.Query())
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
+		return
+	}
+
+	h.log(r).WithField("query", r.URL.RawQuery).Info("Received request to list audit trail")
+	page, err := h.service.ListAuditoria(r.Context(), query)
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to list audit trail")
+		h.sendError(w, r, err, "error.list_auditoria")
+		return
+	}
+
+	h.log(r).WithField("total", page.Meta.Total).Info("Successfully listed audit trail")
+	h.sendResponse(w, r, http.StatusOK, page)
+}
+
+// GetHistorico retorna o histórico de alterações de um aluno
+// @Summary Histórico de alterações de um aluno
+// @Description Retorna as alterações do aluno, da mais recente para a mais antiga, inclusive depois que ele é removido. Depois do expurgo, as entradas ficam sem os campos alterados
+// @Tags Auditoria
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Aluno"
+// @Param limit query int false "Quantidade máxima de entradas na página (padrão 50, máximo 500)"
+// @Param offset query int false "Quantidade de entradas a pular"
+// @Success 200 {object} models.AuditoriaPage
+// @Failure 400 {object} problem.Problem "ID ou parâmetros de consulta inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Router /alunos/{id}/historico [get]
+func (h *AuditoriaHandler) GetHistorico(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	alunoID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	limit, err := parseIntParam(r.URL.Query(), "limit")
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
+		return
+	}
+	offset, err := parseIntParam(r.URL.Query(), "offset")
+	if err != nil {
+		h.log(r).WithError(err).Error("Invalid query parameters")
+		h.sendError(w, r, err, "request.invalid_query")
+		return
+	}
+
+	h.log(r).WithField("aluno_id", alunoID).Info("Received request to get student history")
+	page, err := h.service.HistoricoAluno(r.Context(), alunoID, limit, offset)
+	if err != nil {
+		h.log(r).WithField("aluno_id", alunoID).WithError(err).Error("Failed to get student history")
+		h.sendError(w, r, err, "error.get_historico")
+		return
+	}
+
+	h.log(r).WithFields(logrus.Fields{"aluno_id": alunoID, "total": page.Meta.Total}).Info("Successfully retrieved student history")
+	h.sendResponse(w, r, http.StatusOK, page)
+}
+
+func parseAuditoriaQuery(values url.Values) (models.AuditoriaQuery, error) {
+	var query models.AuditoriaQuery
+	var err error
+
+	if query.Limit, err = parseIntParam(values, "limit"); err != nil {
+		return query, err
+	}
+	if query.Offset, err = parseIntParam(values, "offset"); err != nil {
+		return query, err
+	}
+	if query.Limit < 0 || query.Offset < 0 {
+		return query, models.InvalidQuery("query.negative_pagination")
+	}
+	if query.AlunoID, err = parseOptionalInt(values, "aluno_id"); err != nil {
+		return query, err
+	}
+	query.Ator = values.Get("ator")
+	query.Operacao = values.Get("operacao")
+
+	if query.Desde, err = parseOptionalTime(values, "desde", false); err != nil {
+		return query, err
+	}
+	if query.Ate, err = parseOptionalTime(values, "ate", true); err != nil {
+		return query, err
+	}
+	return query, nil
+}
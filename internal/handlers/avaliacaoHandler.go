@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+type AvaliacaoHandler struct {
+	baseHandler
+	service services.AvaliacaoService
+}
+
+func NewAvaliacaoHandler(service services.AvaliacaoService, logger *logrus.Logger) *AvaliacaoHandler {
+	return &AvaliacaoHandler{baseHandler{logger}, service}
+}
+
+// GetNotas retorna as notas de um aluno
+// @Summary Retorna as notas de um aluno
+// @Description Obtém todas as avaliações do aluno, ordenadas por disciplina, bimestre e descrição
+// @Tags Notas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Aluno"
+// @Success 200 {array} models.Avaliacao
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/{id}/notas [get]
+func (h *AvaliacaoHandler) GetNotas(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	alunoID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("aluno_id", alunoID).Info("Received request to get student grades")
+
+	notas, err := h.service.ListNotas(r.Context(), alunoID)
+	if err != nil {
+		h.log(r).WithField("aluno_id", alunoID).WithError(err).Error("Failed to get student grades")
+		h.sendError(w, r, err, "error.list_notas")
+		return
+	}
+
+	h.log(r).WithField("aluno_id", alunoID).Info("Successfully retrieved student grades")
+	h.sendResponse(w, r, http.StatusOK, notas)
+}
+
+// CreateNota lança uma nota para um aluno
+// @Summary Lança uma nota
+// @Description Registra a nota de uma avaliação do aluno em uma disciplina e bimestre
+// @Tags Notas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Aluno"
+// @Param nota body models.Avaliacao true "Dados da Avaliação"
+// @Success 201 {object} models.Avaliacao
+// @Failure 400 {object} problem.Problem "JSON inválido ou ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Aluno não encontrado"
+// @Failure 409 {object} problem.Problem "Avaliação já lançada"
+// @Failure 422 {object} problem.Problem "Disciplina, bimestre, descrição ou nota inválidos"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/{id}/notas [post]
+func (h *AvaliacaoHandler) CreateNota(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	alunoID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	var avaliacao models.Avaliacao
+	if err := json.NewDecoder(r.Body).Decode(&avaliacao); err != nil {
+		h.log(r).WithError(err).Error("Failed to decode grade data")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_avaliacao")
+		return
+	}
+	avaliacao.AlunoID = alunoID
+
+	h.log(r).WithField("avaliacao", avaliacao).Info("Received request to create a grade")
+
+	if err := h.service.CreateNota(r.Context(), &avaliacao); err != nil {
+		h.log(r).WithError(err).Error("Failed to create a grade")
+		h.sendError(w, r, err, "error.create_nota")
+		return
+	}
+
+	h.log(r).WithField("avaliacao", avaliacao).Info("Successfully created a grade")
+	h.sendResponse(w, r, http.StatusCreated, avaliacao)
+}
+
+// UpdateNota atualiza uma nota de um aluno
+// @Summary Atualiza uma nota
+// @Description Atualiza a disciplina, o bimestre, a descrição ou o valor de uma nota do aluno
+// @Tags Notas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Aluno"
+// @Param notaId path int true "ID da Nota"
+// @Param nota body models.Avaliacao true "Dados da Avaliação"
+// @Success 200 {object} models.Avaliacao
+// @Failure 400 {object} problem.Problem "JSON inválido ou ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Nota não encontrada"
+// @Failure 409 {object} problem.Problem "Avaliação já lançada"
+// @Failure 422 {object} problem.Problem "Disciplina, bimestre, descrição ou nota inválidos"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/{id}/notas/{notaId} [put]
+func (h *AvaliacaoHandler) UpdateNota(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	alunoID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+	id, err := strconv.Atoi(vars["notaId"])
+	if err != nil {
+		h.log(r).WithField("nota_id", vars["notaId"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	var avaliacao models.Avaliacao
+	if err := json.NewDecoder(r.Body).Decode(&avaliacao); err != nil {
+		h.log(r).WithError(err).Error("Failed to decode grade data for update")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_avaliacao")
+		return
+	}
+	avaliacao.ID = id
+	avaliacao.AlunoID = alunoID
+
+	h.log(r).WithField("avaliacao", avaliacao).Info("Received request to update grade")
+
+	if err := h.service.UpdateNota(r.Context(), &avaliacao); err != nil {
+		h.log(r).WithError(err).Error("Failed to update grade")
+		h.sendError(w, r, err, "error.update_nota")
+		return
+	}
+
+	h.log(r).WithField("avaliacao", avaliacao).Info("Successfully updated grade")
+	h.sendResponse(w, r, http.StatusOK, avaliacao)
+}
+
+// DeleteNota remove uma nota de um aluno
+// @Summary Deleta uma nota
+// @Description Remove uma nota específica do aluno
+// @Tags Notas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Aluno"
+// @Param notaId path int true "ID da Nota"
+// @Success 204 "No Content"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Nota não encontrada"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /alunos/{id}/notas/{notaId} [delete]
+func (h *AvaliacaoHandler) DeleteNota(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	alunoID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+	id, err := strconv.Atoi(vars["notaId"])
+	if err != nil {
+		h.log(r).WithField("nota_id", vars["notaId"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithFields(logrus.Fields{"aluno_id": alunoID, "nota_id": id}).Info("Received request to delete grade")
+
+	if err := h.service.DeleteNota(r.Context(), alunoID, id); err != nil {
+		h.log(r).WithFields(logrus.Fields{"aluno_id": alunoID, "nota_id": id}).WithError(err).Error("Failed to delete grade")
+		h.sendError(w, r, err, "error.delete_nota")
+		return
+	}
+
+	h.log(r).WithFields(logrus.Fields{"aluno_id": alunoID, "nota_id": id}).Info("Successfully deleted grade")
+	w.WriteHeader(http.StatusNoContent)
+}
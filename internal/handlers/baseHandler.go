@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// baseHandler reúne o logger e os helpers de resposta compartilhados pelos handlers.
+type baseHandler struct {
+	logger *logrus.Logger
+}
+
+// log retorna o logger da requisição, com o request_id e o usuário autenticado.
+func (h *baseHandler) log(r *http.Request) *logrus.Entry {
+	return logging.FromContext(r.Context())
+}
+
+// function to send standardized JSON responses
+func (h *baseHandler) sendResponse(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			h.log(r).WithError(err).Error("Failed to encode response data")
+		}
+	}
+}
+
+// sendProblem responde com um problema (RFC 7807) do tipo t, com o detail traduzido da
+// mensagem detailKey do catálogo.
+func (h *baseHandler) sendProblem(w http.ResponseWriter, r *http.Request, t problem.Type, detailKey string, args ...interface{}) {
+	h.writeProblem(w, r, problem.New(t, r, i18n.T(i18n.FromRequest(r), detailKey, args...)))
+}
+
+func (h *baseHandler) writeProblem(w http.ResponseWriter, r *http.Request, p *problem.Problem) {
+	if err := problem.Write(w, p); err != nil {
+		h.log(r).WithError(err).Error("Failed to encode problem response")
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// failingWriter simula a conexão encerrada pelo cliente: toda escrita do corpo falha.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("conexão encerrada")
+}
+
+// TestEncodeFailureLogsRequestID verifica que as falhas ao escrever a resposta são registradas
+// no logger da requisição, com o request_id.
+func TestEncodeFailureLogsRequestID(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		send    func(h *baseHandler, w http.ResponseWriter, r *http.Request)
+	}{
+		{
+			name:    "resposta JSON",
+			message: "Failed to encode response data",
+			send: func(h *baseHandler, w http.ResponseWriter, r *http.Request) {
+				h.sendResponse(w, r, http.StatusOK, map[string]string{"status": "ok"})
+			},
+		},
+		{
+			name:    "problema",
+			message: "Failed to encode problem response",
+			send: func(h *baseHandler, w http.ResponseWriter, r *http.Request) {
+				h.writeProblem(w, r, problem.New(problem.InvalidRequest, r, "detalhe"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&out)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+			h := &baseHandler{logger}
+
+			handler := logging.Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.send(h, w, r)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/alunos", nil)
+			req.Header.Set(problem.RequestIDHeader, "req-123")
+			handler.ServeHTTP(failingWriter{httptest.NewRecorder()}, req)
+
+			found := false
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				var entry map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("decoding log %q: %v", line, err)
+				}
+				if entry["msg"] == tt.message {
+					found = true
+					if entry["request_id"] != "req-123" {
+						t.Errorf("request_id = %v, want req-123", entry["request_id"])
+					}
+				}
+			}
+			if !found {
+				t.Errorf("log %q not written: %s", tt.message, out.String())
+			}
+		})
+	}
+}
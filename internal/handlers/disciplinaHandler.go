@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+type DisciplinaHandler struct {
+	baseHandler
+	service services.DisciplinaService
+}
+
+func NewDisciplinaHandler(service services.DisciplinaService, logger *logrus.Logger) *DisciplinaHandler {
+	return &DisciplinaHandler{baseHandler{logger}, service}
+}
+
+// GetDisciplinas retorna todas as disciplinas cadastradas
+// @Summary Retorna a lista de disciplinas
+// @Description Obtém a lista de todas as disciplinas cadastradas, ordenada por nome
+// @Tags Disciplinas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Success 200 {array} models.Disciplina
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /disciplinas [get]
+func (h *DisciplinaHandler) GetDisciplinas(w http.ResponseWriter, r *http.Request) {
+	h.log(r).Info("Received request to get all subjects")
+	disciplinas, err := h.service.GetAllDisciplinas(r.Context())
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to get all subjects")
+		h.sendProblem(w, r, problem.InternalError, "error.list_disciplinas")
+		return
+	}
+
+	h.log(r).Info("Successfully retrieved all subjects")
+	h.sendResponse(w, r, http.StatusOK, disciplinas)
+}
+
+// GetDisciplina retorna uma disciplina específica
+// @Summary Retorna uma disciplina pelo ID
+// @Description Obtém os dados de uma disciplina específica pelo ID
+// @Tags Disciplinas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID da Disciplina"
+// @Success 200 {object} models.Disciplina "Dados da Disciplina"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 404 {object} problem.Problem "Disciplina não encontrada"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /disciplinas/{id} [get]
+func (h *DisciplinaHandler) GetDisciplina(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to get a subject by ID")
+
+	disciplina, err := h.service.GetDisciplinaByID(r.Context(), id)
+	if err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to get subject by ID")
+		h.sendError(w, r, err, "error.get_disciplina")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully retrieved subject by ID")
+	h.sendResponse(w, r, http.StatusOK, disciplina)
+}
+
+// CreateDisciplina cria uma nova disciplina
+// @Summary Cria uma nova disciplina
+// @Description Adiciona uma nova disciplina ao sistema
+// @Tags Disciplinas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param disciplina body models.Disciplina true "Dados da Disciplina"
+// @Success 201 {object} models.Disciplina
+// @Failure 400 {object} problem.Problem "Dados da disciplina inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 409 {object} problem.Problem "Já existe uma disciplina com esse nome"
+// @Failure 422 {object} problem.Problem "Nome da disciplina em branco ou longo demais"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /disciplinas [post]
+func (h *DisciplinaHandler) CreateDisciplina(w http.ResponseWriter, r *http.Request) {
+	var disciplina models.Disciplina
+	if err := json.NewDecoder(r.Body).Decode(&disciplina); err != nil || strings.TrimSpace(disciplina.Nome) == "" {
+		h.log(r).WithError(err).Error("Failed to decode subject data")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_disciplina")
+		return
+	}
+
+	h.log(r).WithField("disciplina", disciplina).Info("Received request to create a new subject")
+
+	if err := h.service.CreateDisciplina(r.Context(), &disciplina); err != nil {
+		h.log(r).WithError(err).Error("Failed to create a new subject")
+		h.sendError(w, r, err, "error.create_disciplina")
+		return
+	}
+
+	h.log(r).WithField("disciplina", disciplina).Info("Successfully created a new subject")
+	h.sendResponse(w, r, http.StatusCreated, disciplina)
+}
+
+// UpdateDisciplina atualiza os dados de uma disciplina
+// @Summary Atualiza os dados de uma disciplina
+// @Description Atualiza as informações de uma disciplina específica pelo ID
+// @Tags Disciplinas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID da Disciplina"
+// @Param disciplina body models.Disciplina true "Dados da Disciplina"
+// @Success 200 {object} models.Disciplina
+// @Failure 400 {object} problem.Problem "Dados inválidos ou ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 409 {object} problem.Problem "Já existe uma disciplina com esse nome"
+// @Failure 422 {object} problem.Problem "Nome da disciplina em branco ou longo demais"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /disciplinas/{id} [put]
+func (h *DisciplinaHandler) UpdateDisciplina(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	var disciplina models.Disciplina
+	if err := json.NewDecoder(r.Body).Decode(&disciplina); err != nil || strings.TrimSpace(disciplina.Nome) == "" {
+		h.log(r).WithError(err).Error("Failed to decode subject data for update")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_disciplina")
+		return
+	}
+	disciplina.ID = id
+
+	h.log(r).WithField("disciplina", disciplina).Info("Received request to update subject")
+
+	if err := h.service.UpdateDisciplina(r.Context(), &disciplina); err != nil {
+		h.log(r).WithError(err).Error("Failed to update subject")
+		h.sendError(w, r, err, "error.update_disciplina")
+		return
+	}
+
+	h.log(r).WithField("disciplina", disciplina).Info("Successfully updated subject")
+	h.sendResponse(w, r, http.StatusOK, disciplina)
+}
+
+// DeleteDisciplina deleta uma disciplina
+// @Summary Deleta uma disciplina pelo ID
+// @Description Remove uma disciplina específica pelo ID. Disciplinas com avaliações lançadas não podem ser removidas.
+// @Tags Disciplinas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID da Disciplina"
+// @Success 204 "No Content"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 409 {object} problem.Problem "A disciplina possui avaliações lançadas"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /disciplinas/{id} [delete]
+func (h *DisciplinaHandler) DeleteDisciplina(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to delete subject")
+
+	if err := h.service.DeleteDisciplina(r.Context(), id); err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to delete subject")
+		h.sendError(w, r, err, "error.delete_disciplina")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully deleted subject")
+	w.WriteHeader(http.StatusNoContent)
+}
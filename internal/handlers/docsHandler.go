@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+
+	logrus "github.com/sirupsen/logrus"
+	"github.com/swaggo/swag"
+)
+
+// DocsHandler serve o documento Swagger com os textos no idioma da requisição.
+type DocsHandler struct {
+	baseHandler
+}
+
+func NewDocsHandler(logger *logrus.Logger) *DocsHandler {
+	return &DocsHandler{baseHandler{logger}}
+}
+
+// GetDoc substitui o doc.json do Swagger UI. O título e a descrição da API e o summary e a
+// description de cada operação vêm do catálogo de mensagens (chaves swagger.info.* e
+// swagger.<MÉTODO> <rota>.*); os textos sem tradução ficam como nas anotações.
+func (h *DocsHandler) GetDoc(w http.ResponseWriter, r *http.Request) {
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to read swagger doc")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	translated, err := translateDoc([]byte(doc), i18n.FromRequest(r))
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to translate swagger doc")
+		translated = []byte(doc)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(translated); err != nil {
+		h.log(r).WithError(err).Error("Failed to write swagger doc")
+	}
+}
+
+func translateDoc(doc []byte, lang i18n.Lang) ([]byte, error) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return nil, err
+	}
+
+	translate := func(obj map[string]interface{}, prefix string) {
+		for _, field := range []string{"title", "summary", "description"} {
+			if _, ok := obj[field]; !ok {
+				continue
+			}
+			if text, ok := i18n.Lookup(lang, prefix+"."+field); ok {
+				obj[field] = text
+			}
+		}
+	}
+
+	if info, ok := spec["info"].(map[string]interface{}); ok {
+		translate(info, "swagger.info")
+	}
+	paths, _ := spec["paths"].(map[string]interface{})
+	for path, item := range paths {
+		operations, _ := item.(map[string]interface{})
+		for method, op := range operations {
+			if operation, ok := op.(map[string]interface{}); ok {
+				translate(operation, "swagger."+strings.ToUpper(method)+" "+path)
+			}
+		}
+	}
+	return json.Marshal(spec)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	_ "github.com/felipemacedo1/dev-cloud-challenge/docs"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+
+	"github.com/swaggo/swag"
+)
+
+// swaggerKeys retorna as chaves swagger.* que o documento gerado pelo swag pode traduzir.
+func swaggerKeys(t *testing.T) map[string]bool {
+	t.Helper()
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		t.Fatalf("swag.ReadDoc: %v", err)
+	}
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+		t.Fatalf("decoding the swagger doc: %v", err)
+	}
+	keys := map[string]bool{"swagger.info.title": true, "swagger.info.description": true}
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			prefix := "swagger." + strings.ToUpper(method) + " " + path
+			keys[prefix+".summary"], keys[prefix+".description"] = true, true
+		}
+	}
+	return keys
+}
+
+func TestSwaggerCatalog(t *testing.T) {
+	want := swaggerKeys(t)
+	for _, lang := range []i18n.Lang{i18n.PtBR, i18n.En} {
+		raw, err := os.ReadFile("../i18n/locales/" + string(lang) + ".json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(raw, &catalog); err != nil {
+			t.Fatalf("%s: %v", lang, err)
+		}
+		for key := range want {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s: missing %q", lang, key)
+			}
+		}
+		for key := range catalog {
+			if strings.HasPrefix(key, "swagger.") && !want[key] {
+				t.Errorf("%s: %q does not match any operation of the swagger doc", lang, key)
+			}
+		}
+	}
+}
+
+func TestTranslateDoc(t *testing.T) {
+	doc := `{"info":{"title":"API de Gestão de Alunos"},"paths":{"/alunos":{"get":{"summary":"Retorna a lista paginada de alunos","tags":["Alunos"]}},"/x":{"get":{"summary":"Sem tradução"}}}}`
+	translated, err := translateDoc([]byte(doc), i18n.En)
+	if err != nil {
+		t.Fatalf("translateDoc: %v", err)
+	}
+	var spec struct {
+		Info  map[string]string                            `json:"info"`
+		Paths map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(translated, &spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.Info["title"]; got != "Student Management API" {
+		t.Errorf("info.title = %q", got)
+	}
+	if got := spec.Paths["/alunos"]["get"]["summary"]; got != "Returns the paginated list of students" {
+		t.Errorf("GET /alunos summary = %q", got)
+	}
+	if got := spec.Paths["/x"]["get"]["summary"]; got != "Sem tradução" {
+		t.Errorf("untranslated summary = %q, want the annotation text", got)
+	}
+	if _, ok := spec.Paths["/alunos"]["get"]["description"]; ok {
+		t.Error("translateDoc added a description that the operation does not have")
+	}
+}
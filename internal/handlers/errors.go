@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+)
+
+// problemTypeForError escolhe o tipo de problema (e, com ele, o status HTTP) de um erro
+// retornado pelos serviços a partir da sua categoria de domínio. Erros sem categoria são
+// falhas internas.
+func problemTypeForError(err error) problem.Type {
+	switch {
+	case errors.Is(err, models.ErrInvalidQuery):
+		return problem.InvalidRequest
+	case errors.Is(err, models.ErrForbidden):
+		return problem.Forbidden
+	case errors.Is(err, models.ErrNotFound):
+		return problem.NotFound
+	case errors.Is(err, models.ErrConflict):
+		return problem.Conflict
+	case errors.Is(err, models.ErrPreconditionFailed):
+		return problem.PreconditionFailed
+	case errors.Is(err, models.ErrValidation):
+		return problem.ValidationError
+	default:
+		return problem.InternalError
+	}
+}
+
+// sendError responde com o problema correspondente ao erro. Erros de domínio levam a própria
+// mensagem no detail; falhas internas respondem com a mensagem fallbackKey, sem expor detalhes.
+func (h *baseHandler) sendError(w http.ResponseWriter, r *http.Request, err error, fallbackKey string) {
+	t := problemTypeForError(err)
+	if t == problem.InternalError {
+		h.sendProblem(w, r, t, fallbackKey)
+		return
+	}
+
+	lang := i18n.FromRequest(r)
+	p := problem.New(t, r, errorMessage(err, lang))
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		p.Detail = i18n.T(lang, "request.validation_failed")
+		p.Errors = make([]models.FieldError, len(validationErr.Errors))
+		for i, fe := range validationErr.Errors {
+			p.Errors[i] = fe.Localize(lang)
+		}
+	}
+	h.writeProblem(w, r, p)
+}
+
+// errorMessage retorna a mensagem do erro no idioma pedido, quando ela vem do catálogo.
+func errorMessage(err error, lang i18n.Lang) string {
+	var localizer i18n.Localizer
+	if errors.As(err, &localizer) {
+		return capitalize(localizer.Localize(lang))
+	}
+	return capitalize(err.Error())
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+func TestProblemTypeForError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want problem.Type
+	}{
+		{models.ErrAlunoNotFound, problem.NotFound},
+		{fmt.Errorf("buscando: %w", models.ErrSalaNotFound), problem.NotFound},
+		{models.ErrSalaFull, problem.Conflict},
+		{models.ErrDuplicateProfessor, problem.Conflict},
+		{models.ErrAlunoVersionMismatch, problem.PreconditionFailed},
+		{models.ErrAccessDenied, problem.Forbidden},
+		{models.InvalidQuery("query.unknown_situacao", "x"), problem.InvalidRequest},
+		{&models.ValidationError{Errors: []models.FieldError{models.NewFieldError("nome", models.CodeRequired, "validation.required")}}, problem.ValidationError},
+		{errors.New("conexão perdida"), problem.InternalError},
+	}
+	for _, tt := range tests {
+		if got := problemTypeForError(tt.err); got != tt.want {
+			t.Errorf("problemTypeForError(%v) = %s, want %s", tt.err, got.Slug, tt.want.Slug)
+		}
+	}
+}
+
+func TestSendError(t *testing.T) {
+	validation := &models.ValidationError{Errors: []models.FieldError{
+		models.NewFieldError("nome", models.CodeRequired, "validation.required"),
+		models.NewFieldError("numero_sala", models.CodeNotFound, "validation.sala_not_found", 999),
+	}}
+	tests := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		wantStatus     int
+		wantType       string
+		wantTitle      string
+		wantDetail     string
+		wantErrors     []models.FieldError
+	}{
+		{
+			name:       "não encontrado",
+			err:        models.ErrAlunoNotFound,
+			wantStatus: http.StatusNotFound,
+			wantType:   "/problems/not-found",
+			wantTitle:  "Recurso não encontrado",
+			wantDetail: "Aluno não encontrado",
+		},
+		{
+			name:           "não encontrado, em inglês",
+			err:            models.ErrAlunoNotFound,
+			acceptLanguage: "en-US,en;q=0.9",
+			wantStatus:     http.StatusNotFound,
+			wantType:       "/problems/not-found",
+			wantTitle:      "Resource not found",
+			wantDetail:     "Student not found",
+		},
+		{
+			name:       "conflito",
+			err:        models.ErrSalaFull,
+			wantStatus: http.StatusConflict,
+			wantType:   "/problems/conflict",
+			wantDetail: "A sala informada está com a capacidade esgotada",
+		},
+		{
+			name:       "falha interna não expõe o erro",
+			err:        errors.New("pq: conexão perdida com 10.0.0.5"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "/problems/internal-error",
+			wantTitle:  "Erro interno no servidor",
+			wantDetail: "Erro ao obter aluno",
+		},
+		{
+			name:           "validação, em inglês",
+			err:            validation,
+			acceptLanguage: "en",
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantType:       "/problems/validation-error",
+			wantDetail:     "One or more fields are invalid",
+			wantErrors: []models.FieldError{
+				{Field: "nome", Code: models.CodeRequired, Message: "required field"},
+				{Field: "numero_sala", Code: models.CodeNotFound, Message: "classroom 999 does not exist"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &baseHandler{logger: logrus.New()}
+			handler := i18n.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				h.sendError(w, r, tt.err, "error.get_aluno")
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/alunos/42", nil)
+			req.Header.Set(problem.RequestIDHeader, "req-1")
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != problem.ContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, problem.ContentType)
+			}
+			body, _ := io.ReadAll(rec.Body)
+			var p problem.Problem
+			if err := json.Unmarshal(body, &p); err != nil {
+				t.Fatalf("decoding %s: %v", body, err)
+			}
+			if p.Type != tt.wantType || p.Status != tt.wantStatus || p.Detail != tt.wantDetail {
+				t.Errorf("problem = %+v, want type %q, status %d, detail %q", p, tt.wantType, tt.wantStatus, tt.wantDetail)
+			}
+			if tt.wantTitle != "" && p.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", p.Title, tt.wantTitle)
+			}
+			if p.Instance != "/alunos/42" || p.RequestID != "req-1" {
+				t.Errorf("instance, request_id = %q, %q, want /alunos/42, req-1", p.Instance, p.RequestID)
+			}
+			if len(p.Errors) != len(tt.wantErrors) {
+				t.Fatalf("errors = %+v, want %+v", p.Errors, tt.wantErrors)
+			}
+			for i := range p.Errors {
+				got, want := p.Errors[i], tt.wantErrors[i]
+				if got.Field != want.Field || got.Code != want.Code || got.Message != want.Message {
+					t.Errorf("errors[%d] = %+v, want %+v", i, p.Errors[i], tt.wantErrors[i])
+				}
+			}
+		})
+	}
+}
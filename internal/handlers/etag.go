@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+)
+
+var (
+	errIfMatchMissing = errors.New("cabeçalho If-Match ausente")
+	errIfMatchInvalid = errors.New("cabeçalho If-Match inválido")
+)
+
+// alunoETag é o ETag do aluno: a sua versão, como entity-tag forte.
+func alunoETag(aluno *models.Aluno) string {
+	return `"` + strconv.Itoa(aluno.Version) + `"`
+}
+
+func setAlunoETag(w http.ResponseWriter, aluno *models.Aluno) {
+	w.Header().Set("ETag", alunoETag(aluno))
+}
+
+// parseIfMatch retorna a versão exigida pelo If-Match, ou models.AnyVersion para "*". Apenas um
+// ETag forte, como os retornados pela API, é aceito.
+func parseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	switch {
+	case header == "":
+		return 0, errIfMatchMissing
+	case header == "*":
+		return models.AnyVersion, nil
+	}
+	unquoted, ok := strings.CutPrefix(header, `"`)
+	if !ok {
+		return 0, errIfMatchInvalid
+	}
+	unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	if !ok {
+		return 0, errIfMatchInvalid
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= models.AnyVersion {
+		return 0, errIfMatchInvalid
+	}
+	return version, nil
+}
+
+// ifMatchVersion lê a versão esperada pelo cliente para alterar o aluno. Sem If-Match responde
+// 428, para que alterações não sobrescrevam sem saber mudanças feitas por outros clientes;
+// um If-Match que não pode corresponder a nenhuma versão responde 412.
+func (h *AlunoHandler) ifMatchVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	version, err := parseIfMatch(r.Header.Get("If-Match"))
+	switch {
+	case errors.Is(err, errIfMatchMissing):
+		h.log(r).Error("Missing If-Match header")
+		h.sendProblem(w, r, problem.PreconditionRequired, "request.if_match_required")
+		return 0, false
+	case err != nil:
+		h.log(r).WithField("if_match", r.Header.Get("If-Match")).Error("Invalid If-Match header")
+		h.sendProblem(w, r, problem.PreconditionFailed, "request.if_match_invalid")
+		return 0, false
+	}
+	return version, true
+}
+
+// noneMatch informa se o If-None-Match da requisição corresponde ao ETag, pela comparação fraca
+// (RFC 9110): o cliente já tem a representação atual.
+func noneMatch(r *http.Request, etag string) bool {
+	header := strings.TrimSpace(r.Header.Get("If-None-Match"))
+	if header == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// healthTimeout limita a espera pelo banco na verificação de saúde.
+const healthTimeout = 2 * time.Second
+
+// HealthHandler responde à verificação de saúde usada pela plataforma e pelos balanceadores.
+// A rota é pública, sem autenticação.
+type HealthHandler struct {
+	baseHandler
+	db *sql.DB
+}
+
+func NewHealthHandler(db *sql.DB, logger *logrus.Logger) *HealthHandler {
+	return &HealthHandler{baseHandler{logger}, db}
+}
+
+type healthStatus struct {
+	Status   string `json:"status" example:"ok"`
+	Database string `json:"database" example:"ok"`
+}
+
+// GetHealth verifica se a API e o banco de dados estão disponíveis
+// @Summary Verifica a saúde da API
+// @Description Retorna 200 quando a API e o banco de dados respondem, ou 503 quando o banco está indisponível
+// @Tags Saúde
+// @Produce  json
+// @Success 200 {object} healthStatus
+// @Failure 503 {object} healthStatus
+// @Router /health [get]
+func (h *HealthHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		h.log(r).WithError(err).Error("Health check failed to reach the database")
+		h.sendResponse(w, r, http.StatusServiceUnavailable, healthStatus{Status: "unavailable", Database: "unavailable"})
+		return
+	}
+	h.sendResponse(w, r, http.StatusOK, healthStatus{Status: "ok", Database: "ok"})
+}
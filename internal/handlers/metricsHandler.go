@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// MetricsHandler publica as métricas para o Prometheus. A rota não usa a autenticação da API:
+// na porta da API, o Prometheus deve enviar o METRICAS_TOKEN como "Authorization: Bearer
+// <token>"; no endereço interno das métricas, o token só é exigido quando configurado.
+type MetricsHandler struct {
+	baseHandler
+	metrics http.Handler
+	token   string
+}
+
+func NewMetricsHandler(metrics http.Handler, token string, logger *logrus.Logger) *MetricsHandler {
+	return &MetricsHandler{baseHandler{logger}, metrics, token}
+}
+
+// GetMetrics retorna as métricas da API no formato de exposição do Prometheus
+// @Summary Métricas para o Prometheus
+// @Description Requisições HTTP por rota, conexões com o banco, duração dos repositórios e indicadores do cadastro, no formato de exposição em texto do Prometheus. Servida na porta da API apenas com o METRICAS_TOKEN configurado; com o METRICAS_ENDERECO, apenas nesse endereço interno
+// @Tags Saúde
+// @Produce  plain
+// @Success 200 {string} string
+// @Failure 401 {object} problem.Problem "Token das métricas ausente ou inválido"
+// @Router /metrics [get]
+func (h *MetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	if h.token != "" && !h.authorized(r) {
+		h.log(r).Warn("Rejected metrics scrape")
+		w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+		h.sendProblem(w, r, problem.Unauthorized, "auth.invalid_token")
+		return
+	}
+	h.metrics.ServeHTTP(w, r)
+}
+
+func (h *MetricsHandler) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
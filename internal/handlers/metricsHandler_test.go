@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+func TestGetMetricsToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "endereço interno sem token", wantStatus: http.StatusOK},
+		{name: "token ausente", token: "segredo", wantStatus: http.StatusUnauthorized},
+		{name: "token inválido", token: "segredo", authorization: "Bearer outro", wantStatus: http.StatusUnauthorized},
+		{name: "esquema inválido", token: "segredo", authorization: "Basic segredo", wantStatus: http.StatusUnauthorized},
+		{name: "token válido", token: "segredo", authorization: "Bearer segredo", wantStatus: http.StatusOK},
+	}
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("http_requests_in_flight 0\n"))
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewMetricsHandler(metrics, tt.token, logrus.New())
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			h.GetMetrics(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusUnauthorized {
+				if rec.Header().Get("WWW-Authenticate") == "" {
+					t.Error("401 without WWW-Authenticate")
+				}
+				if rec.Body.String() == "http_requests_in_flight 0\n" {
+					t.Error("401 response exposes the metrics")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// ProblemHandler publica o catálogo de tipos de problema e responde às rotas inexistentes
+// no mesmo formato.
+type ProblemHandler struct {
+	baseHandler
+}
+
+func NewProblemHandler(logger *logrus.Logger) *ProblemHandler {
+	return &ProblemHandler{baseHandler{logger}}
+}
+
+// GetProblemTypes lista o catálogo de tipos de problema
+// @Summary Lista os tipos de problema
+// @Description Retorna o catálogo estável de URIs usadas no campo type das respostas de erro (RFC 7807)
+// @Tags Problemas
+// @Produce  json
+// @Success 200 {array} problem.Description
+// @Router /problems [get]
+func (h *ProblemHandler) GetProblemTypes(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.FromRequest(r)
+	types := make([]problem.Description, len(problem.Catalog))
+	for i, t := range problem.Catalog {
+		types[i] = t.Describe(lang)
+	}
+	h.sendResponse(w, r, http.StatusOK, types)
+}
+
+// GetProblemType descreve um tipo de problema
+// @Summary Descreve um tipo de problema
+// @Description Retorna a descrição do tipo de problema identificado pela URI /problems/{slug}
+// @Tags Problemas
+// @Produce  json
+// @Produce  application/problem+json
+// @Param slug path string true "Identificador do tipo de problema"
+// @Success 200 {object} problem.Description
+// @Failure 404 {object} problem.Problem "Tipo de problema não encontrado"
+// @Router /problems/{slug} [get]
+func (h *ProblemHandler) GetProblemType(w http.ResponseWriter, r *http.Request) {
+	t, ok := problem.Lookup(mux.Vars(r)["slug"])
+	if !ok {
+		h.sendProblem(w, r, problem.NotFound, "request.problem_type_not_found")
+		return
+	}
+	h.sendResponse(w, r, http.StatusOK, t.Describe(i18n.FromRequest(r)))
+}
+
+// NotFound responde às rotas não cadastradas.
+func (h *ProblemHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	h.sendProblem(w, r, problem.NotFound, "request.route_not_found")
+}
+
+// MethodNotAllowed responde às rotas cadastradas chamadas com um método não suportado.
+func (h *ProblemHandler) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	h.sendProblem(w, r, problem.MethodNotAllowed, "request.method_not_allowed", r.Method)
+}
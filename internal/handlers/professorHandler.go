@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+type ProfessorHandler struct {
+	baseHandler
+	service services.ProfessorService
+}
+
+func NewProfessorHandler(service services.ProfessorService, logger *logrus.Logger) *ProfessorHandler {
+	return &ProfessorHandler{baseHandler{logger}, service}
+}
+
+// GetProfessores retorna todos os professores cadastrados
+// @Summary Retorna a lista de professores
+// @Description Obtém a lista de todos os professores cadastrados, ordenada por nome
+// @Tags Professores
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Success 200 {array} models.Professor
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /professores [get]
+func (h *ProfessorHandler) GetProfessores(w http.ResponseWriter, r *http.Request) {
+	h.log(r).Info("Received request to get all professors")
+	professores, err := h.service.GetAllProfessores(r.Context())
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to get all professors")
+		h.sendError(w, r, err, "error.list_professores")
+		return
+	}
+
+	h.log(r).Info("Successfully retrieved all professors")
+	h.sendResponse(w, r, http.StatusOK, professores)
+}
+
+// GetProfessor retorna um professor específico
+// @Summary Retorna um professor pelo ID
+// @Description Obtém os dados de um professor específico pelo ID
+// @Tags Professores
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Professor"
+// @Success 200 {object} models.Professor "Dados do Professor"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 404 {object} problem.Problem "Professor não encontrado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /professores/{id} [get]
+func (h *ProfessorHandler) GetProfessor(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to get a professor by ID")
+
+	professor, err := h.service.GetProfessorByID(r.Context(), id)
+	if err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to get professor by ID")
+		h.sendError(w, r, err, "error.get_professor")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully retrieved professor by ID")
+	h.sendResponse(w, r, http.StatusOK, professor)
+}
+
+// CreateProfessor cria um novo professor
+// @Summary Cria um novo professor
+// @Description Adiciona um novo professor ao sistema. O título ("Prof.", "Profa.") é removido do nome.
+// @Tags Professores
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param professor body models.Professor true "Dados do Professor"
+// @Success 201 {object} models.Professor
+// @Failure 400 {object} problem.Problem "Dados do professor inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 409 {object} problem.Problem "Já existe um professor com esse nome"
+// @Failure 422 {object} problem.Problem "Nome do professor em branco ou longo demais"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /professores [post]
+func (h *ProfessorHandler) CreateProfessor(w http.ResponseWriter, r *http.Request) {
+	var professor models.Professor
+	if err := json.NewDecoder(r.Body).Decode(&professor); err != nil || strings.TrimSpace(professor.Nome) == "" {
+		h.log(r).WithError(err).Error("Failed to decode professor data")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_professor")
+		return
+	}
+
+	h.log(r).WithField("professor", professor).Info("Received request to create a new professor")
+
+	if err := h.service.CreateProfessor(r.Context(), &professor); err != nil {
+		h.log(r).WithError(err).Error("Failed to create a new professor")
+		h.sendError(w, r, err, "error.create_professor")
+		return
+	}
+
+	h.log(r).WithField("professor", professor).Info("Successfully created a new professor")
+	h.sendResponse(w, r, http.StatusCreated, professor)
+}
+
+// UpdateProfessor atualiza os dados de um professor
+// @Summary Atualiza os dados de um professor
+// @Description Atualiza as informações de um professor específico pelo ID
+// @Tags Professores
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Professor"
+// @Param professor body models.Professor true "Dados do Professor"
+// @Success 200 {object} models.Professor
+// @Failure 400 {object} problem.Problem "Dados inválidos ou ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Professor não encontrado"
+// @Failure 409 {object} problem.Problem "Já existe um professor com esse nome"
+// @Failure 422 {object} problem.Problem "Nome do professor em branco ou longo demais"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /professores/{id} [put]
+func (h *ProfessorHandler) UpdateProfessor(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	var professor models.Professor
+	if err := json.NewDecoder(r.Body).Decode(&professor); err != nil || strings.TrimSpace(professor.Nome) == "" {
+		h.log(r).WithError(err).Error("Failed to decode professor data for update")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_professor")
+		return
+	}
+	professor.ID = id
+
+	h.log(r).WithField("professor", professor).Info("Received request to update professor")
+
+	if err := h.service.UpdateProfessor(r.Context(), &professor); err != nil {
+		h.log(r).WithError(err).Error("Failed to update professor")
+		h.sendError(w, r, err, "error.update_professor")
+		return
+	}
+
+	h.log(r).WithField("professor", professor).Info("Successfully updated professor")
+	h.sendResponse(w, r, http.StatusOK, professor)
+}
+
+// DeleteProfessor deleta um professor
+// @Summary Deleta um professor pelo ID
+// @Description Remove um professor específico pelo ID. Os alunos do professor ficam sem professor associado.
+// @Tags Professores
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID do Professor"
+// @Success 204 "No Content"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Professor não encontrado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /professores/{id} [delete]
+func (h *ProfessorHandler) DeleteProfessor(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to delete professor")
+
+	if err := h.service.DeleteProfessor(r.Context(), id); err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to delete professor")
+		h.sendError(w, r, err, "error.delete_professor")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully deleted professor")
+	w.WriteHeader(http.StatusNoContent)
+}
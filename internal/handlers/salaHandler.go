@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+type SalaHandler struct {
+	baseHandler
+	service services.SalaService
+}
+
+func NewSalaHandler(service services.SalaService, logger *logrus.Logger) *SalaHandler {
+	return &SalaHandler{baseHandler{logger}, service}
+}
+
+// GetSalas retorna todas as salas cadastradas
+// @Summary Retorna a lista de salas
+// @Description Obtém a lista de todas as salas cadastradas, com a ocupação atual de cada uma
+// @Tags Salas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Success 200 {array} models.Sala
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /salas [get]
+func (h *SalaHandler) GetSalas(w http.ResponseWriter, r *http.Request) {
+	h.log(r).Info("Received request to get all rooms")
+	salas, err := h.service.GetAllSalas(r.Context())
+	if err != nil {
+		h.log(r).WithError(err).Error("Failed to get all rooms")
+		h.sendProblem(w, r, problem.InternalError, "error.list_salas")
+		return
+	}
+
+	h.log(r).Info("Successfully retrieved all rooms")
+	h.sendResponse(w, r, http.StatusOK, salas)
+}
+
+// GetSala retorna uma sala específica
+// @Summary Retorna uma sala pelo ID
+// @Description Obtém os dados e a ocupação de uma sala específica pelo ID
+// @Tags Salas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID da Sala"
+// @Success 200 {object} models.Sala "Dados da Sala"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 404 {object} problem.Problem "Sala não encontrada"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /salas/{id} [get]
+func (h *SalaHandler) GetSala(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to get a room by ID")
+
+	sala, err := h.service.GetSalaByID(r.Context(), id)
+	if err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to get room by ID")
+		h.sendError(w, r, err, "error.get_sala")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully retrieved room by ID")
+	h.sendResponse(w, r, http.StatusOK, sala)
+}
+
+// CreateSala cria uma nova sala
+// @Summary Cria uma nova sala
+// @Description Adiciona uma nova sala ao sistema
+// @Tags Salas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param sala body models.Sala true "Dados da Sala"
+// @Success 201 {object} models.Sala
+// @Failure 400 {object} problem.Problem "Dados da sala inválidos"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 409 {object} problem.Problem "Já existe uma sala com esse número"
+// @Failure 422 {object} problem.Problem "Prédio longo demais"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /salas [post]
+func (h *SalaHandler) CreateSala(w http.ResponseWriter, r *http.Request) {
+	var sala models.Sala
+	if err := json.NewDecoder(r.Body).Decode(&sala); err != nil || sala.Capacidade <= 0 {
+		h.log(r).WithError(err).Error("Failed to decode room data")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_sala")
+		return
+	}
+
+	h.log(r).WithField("sala", sala).Info("Received request to create a new room")
+
+	if err := h.service.CreateSala(r.Context(), &sala); err != nil {
+		h.log(r).WithError(err).Error("Failed to create a new room")
+		h.sendError(w, r, err, "error.create_sala")
+		return
+	}
+
+	h.log(r).WithField("sala", sala).Info("Successfully created a new room")
+	h.sendResponse(w, r, http.StatusCreated, sala)
+}
+
+// UpdateSala atualiza os dados de uma sala
+// @Summary Atualiza os dados de uma sala
+// @Description Atualiza as informações de uma sala específica pelo ID. A capacidade não pode ficar abaixo da ocupação atual.
+// @Tags Salas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID da Sala"
+// @Param sala body models.Sala true "Dados da Sala"
+// @Success 200 {object} models.Sala
+// @Failure 400 {object} problem.Problem "Dados inválidos ou ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 404 {object} problem.Problem "Sala não encontrada"
+// @Failure 409 {object} problem.Problem "Número duplicado ou capacidade menor que a ocupação"
+// @Failure 422 {object} problem.Problem "Prédio longo demais"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /salas/{id} [put]
+func (h *SalaHandler) UpdateSala(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	var sala models.Sala
+	if err := json.NewDecoder(r.Body).Decode(&sala); err != nil || sala.Capacidade <= 0 {
+		h.log(r).WithError(err).Error("Failed to decode room data for update")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_sala")
+		return
+	}
+	sala.ID = id
+
+	h.log(r).WithField("sala", sala).Info("Received request to update room")
+
+	if err := h.service.UpdateSala(r.Context(), &sala); err != nil {
+		h.log(r).WithError(err).Error("Failed to update room")
+		h.sendError(w, r, err, "error.update_sala")
+		return
+	}
+
+	h.log(r).WithField("sala", sala).Info("Successfully updated room")
+	h.sendResponse(w, r, http.StatusOK, sala)
+}
+
+// DeleteSala deleta uma sala
+// @Summary Deleta uma sala pelo ID
+// @Description Remove uma sala específica pelo ID. Salas com alunos matriculados, ou com alunos removidos que ainda não foram expurgados, não podem ser removidas.
+// @Tags Salas
+// @Accept  json
+// @Produce  json
+// @Produce  application/problem+json
+// @Param id path int true "ID da Sala"
+// @Success 204 "No Content"
+// @Failure 400 {object} problem.Problem "ID inválido"
+// @Failure 401 {object} problem.Problem "Não autenticado"
+// @Failure 403 {object} problem.Problem "Sem permissão para a operação"
+// @Failure 409 {object} problem.Problem "A sala possui alunos matriculados ou removidos"
+// @Failure 500 {object} problem.Problem "Erro interno no servidor"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /salas/{id} [delete]
+func (h *SalaHandler) DeleteSala(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.log(r).WithField("id", vars["id"]).Error("Invalid ID format")
+		h.sendProblem(w, r, problem.InvalidRequest, "request.invalid_id")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Received request to delete room")
+
+	if err := h.service.DeleteSala(r.Context(), id); err != nil {
+		h.log(r).WithField("id", id).WithError(err).Error("Failed to delete room")
+		h.sendError(w, r, err, "error.delete_sala")
+		return
+	}
+
+	h.log(r).WithField("id", id).Info("Successfully deleted room")
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+// Package httpx reúne o que os middlewares de log, métricas e rastreamento precisam saber da
+// requisição atendida: a rota do mux que a atendeu e o status e o tamanho da resposta.
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// RouteTemplate retorna o template da rota do mux que atende a requisição (como /alunos/{id}),
+// que agrupa as requisições melhor que o caminho, e se alguma rota corresponde a ela.
+func RouteTemplate(r *http.Request) (string, bool) {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// RouteOrPath retorna o template da rota ou, se nenhuma rota corresponder, o caminho.
+func RouteOrPath(r *http.Request) string {
+	if template, ok := RouteTemplate(r); ok {
+		return template
+	}
+	return r.URL.Path
+}
+
+// StatusRecorder registra o status e o tamanho da resposta. Status é 200 até o handler escrever
+// outro status.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status      int
+	Bytes       int
+	wroteHeader bool
+}
+
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+func (rec *StatusRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.Status, rec.wroteHeader = status, true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *StatusRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	n, err := rec.ResponseWriter.Write(b)
+	rec.Bytes += n
+	return n, err
+}
+
+// Unwrap permite ao http.ResponseController chegar ao ResponseWriter original.
+func (rec *StatusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
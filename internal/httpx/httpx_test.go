@@ -0,0 +1,99 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	var template, routeOrPath string
+	var matched bool
+	record := func(w http.ResponseWriter, r *http.Request) {
+		template, matched = RouteTemplate(r)
+		routeOrPath = RouteOrPath(r)
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/alunos/{id}", record)
+	router.NotFoundHandler = http.HandlerFunc(record)
+
+	tests := []struct {
+		path            string
+		wantTemplate    string
+		wantMatched     bool
+		wantRouteOrPath string
+	}{
+		{path: "/alunos/42", wantTemplate: "/alunos/{id}", wantMatched: true, wantRouteOrPath: "/alunos/{id}"},
+		{path: "/inexistente", wantRouteOrPath: "/inexistente"},
+	}
+	for _, tt := range tests {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if template != tt.wantTemplate || matched != tt.wantMatched || routeOrPath != tt.wantRouteOrPath {
+			t.Errorf("%s: RouteTemplate = %q, %v, RouteOrPath = %q, want %q, %v, %q",
+				tt.path, template, matched, routeOrPath, tt.wantTemplate, tt.wantMatched, tt.wantRouteOrPath)
+		}
+	}
+}
+
+func TestStatusRecorder(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantBytes  int
+	}{
+		{name: "sem escrita", handler: func(w http.ResponseWriter, r *http.Request) {}, wantStatus: http.StatusOK},
+		{
+			name:       "corpo sem WriteHeader",
+			handler:    func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("olá")) },
+			wantStatus: http.StatusOK, wantBytes: len("olá"),
+		},
+		{
+			name: "status e corpo",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte("{}"))
+				w.Write([]byte("\n"))
+			},
+			wantStatus: http.StatusNotFound, wantBytes: 3,
+		},
+		{
+			name: "apenas o primeiro WriteHeader",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "WriteHeader depois do corpo é ignorado",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("x"))
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantStatus: http.StatusOK, wantBytes: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewStatusRecorder(httptest.NewRecorder())
+			tt.handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			if rec.Status != tt.wantStatus || rec.Bytes != tt.wantBytes {
+				t.Errorf("Status, Bytes = %d, %d, want %d, %d", rec.Status, rec.Bytes, tt.wantStatus, tt.wantBytes)
+			}
+		})
+	}
+}
+
+func TestStatusRecorderUnwrap(t *testing.T) {
+	w := httptest.NewRecorder()
+	rec := NewStatusRecorder(w)
+	if err := http.NewResponseController(rec).Flush(); err != nil {
+		t.Errorf("Flush through StatusRecorder: %v", err)
+	}
+	if !w.Flushed {
+		t.Error("ResponseController did not reach the original ResponseWriter")
+	}
+}
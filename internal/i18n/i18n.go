@@ -0,0 +1,152 @@
+// Package i18n contém o catálogo de mensagens da API (pt-BR e en) e a escolha do idioma pelo
+// cabeçalho Accept-Language.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Lang string
+
+const (
+	PtBR Lang = "pt-BR"
+	En   Lang = "en"
+
+	// Default é o idioma usado quando o cliente não pede um idioma suportado e quando falta
+	// uma mensagem no idioma pedido.
+	Default = PtBR
+)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var catalogs = loadCatalogs()
+
+// loadCatalogs lê locales/<idioma>.json. Os arquivos fazem parte do binário, então um
+// catálogo inválido é erro de programação.
+func loadCatalogs() map[Lang]map[string]string {
+	files, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	result := map[Lang]map[string]string{}
+	for _, f := range files {
+		data, err := locales.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s inválido: %v", f.Name(), err))
+		}
+		result[Lang(strings.TrimSuffix(f.Name(), ".json"))] = messages
+	}
+	return result
+}
+
+// Lookup retorna a mensagem da chave no idioma pedido, sem fallback.
+func Lookup(lang Lang, key string) (string, bool) {
+	msg, ok := catalogs[lang][key]
+	return msg, ok
+}
+
+// T traduz a chave para o idioma pedido, formatando os argumentos como fmt.Sprintf. Se a
+// chave não existir no idioma, usa o idioma padrão; se não existir em nenhum, retorna a chave.
+func T(lang Lang, key string, args ...interface{}) string {
+	msg, ok := Lookup(lang, key)
+	if !ok {
+		if msg, ok = Lookup(Default, key); !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Message é um texto do catálogo ainda não traduzido, para ser traduzido quando o idioma da
+// resposta for conhecido.
+type Message struct {
+	Key  string
+	Args []interface{}
+}
+
+func NewMessage(key string, args ...interface{}) Message {
+	return Message{key, args}
+}
+
+func (m Message) In(lang Lang) string {
+	return T(lang, m.Key, m.Args...)
+}
+
+// Localizer é implementado pelos erros cuja mensagem vem do catálogo.
+type Localizer interface {
+	Localize(lang Lang) string
+}
+
+// Negotiate escolhe o idioma da resposta a partir do cabeçalho Accept-Language, respeitando
+// os pesos q. Qualquer variante de português ou inglês é aceita ("pt", "pt-PT", "en-US").
+func Negotiate(acceptLanguage string) Lang {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if tag != "" && q > 0 {
+			candidates = append(candidates, candidate{strings.ToLower(tag), q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		base, _, _ := strings.Cut(c.tag, "-")
+		switch base {
+		case "pt", "*":
+			return PtBR
+		case "en":
+			return En
+		}
+	}
+	return Default
+}
+
+type contextKey struct{}
+
+// Middleware negocia o idioma de cada requisição, guarda-o no contexto e informa o idioma
+// escolhido em Content-Language.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(lang))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, lang)))
+	})
+}
+
+// FromRequest retorna o idioma da requisição escolhido pelo Middleware, ou o negocia se a
+// requisição não passou por ele.
+func FromRequest(r *http.Request) Lang {
+	if lang, ok := r.Context().Value(contextKey{}).(Lang); ok {
+		return lang
+	}
+	return Negotiate(r.Header.Get("Accept-Language"))
+}
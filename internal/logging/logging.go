@@ -0,0 +1,51 @@
+// Package logging associa a cada requisição um ID (X-Request-ID) e um logger com esse ID,
+// propagado pelo contexto até os serviços e repositórios, e registra o log de acesso.
+package logging
+
+import (
+	"context"
+	"sync"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// requestLog é o logger da requisição. É guardado no contexto por ponteiro para que os campos
+// acrescentados depois (como o usuário, identificado na autenticação) também apareçam no log
+// de acesso, escrito pelo middleware mais externo. O mutex protege entry das goroutines que o
+// handler inicie com o contexto da requisição.
+type requestLog struct {
+	mu    sync.Mutex
+	entry *logrus.Entry
+}
+
+func (rl *requestLog) current() *logrus.Entry {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.entry
+}
+
+type contextKey struct{}
+
+func withRequestLog(ctx context.Context, entry *logrus.Entry) (context.Context, *requestLog) {
+	rl := &requestLog{entry: entry}
+	return context.WithValue(ctx, contextKey{}, rl), rl
+}
+
+// FromContext retorna o logger da requisição, com o request_id e os campos acrescentados por
+// AddFields. Fora de uma requisição, retorna o logger padrão do logrus.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if rl, ok := ctx.Value(contextKey{}).(*requestLog); ok {
+		return rl.current()
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+// AddFields acrescenta campos a todos os logs seguintes da requisição, inclusive ao log de
+// acesso. Pode ser chamada de várias goroutines.
+func AddFields(ctx context.Context, fields logrus.Fields) {
+	if rl, ok := ctx.Value(contextKey{}).(*requestLog); ok {
+		rl.mu.Lock()
+		rl.entry = rl.entry.WithFields(fields)
+		rl.mu.Unlock()
+	}
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+func TestAddFieldsConcurrent(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	const goroutines = 20
+	handler := Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				AddFields(r.Context(), logrus.Fields{fmt.Sprintf("campo_%d", i): i})
+				FromContext(r.Context()).Debug("concorrente")
+			}(i)
+		}
+		wg.Wait()
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/alunos", nil))
+
+	var access map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &access); err != nil {
+		t.Fatalf("decoding access log %q: %v", out.String(), err)
+	}
+	for i := 0; i < goroutines; i++ {
+		if _, ok := access[fmt.Sprintf("campo_%d", i)]; !ok {
+			t.Errorf("access log misses campo_%d: %v", i, access)
+		}
+	}
+}
+
+func TestFromContextOutsideRequest(t *testing.T) {
+	AddFields(context.Background(), logrus.Fields{"ignorado": true})
+	if entry := FromContext(context.Background()); len(entry.Data) != 0 {
+		t.Errorf("FromContext outside a request = %v, want the standard logger without fields", entry.Data)
+	}
+}
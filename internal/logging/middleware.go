@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/httpx"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// maxRequestIDLength limita o ID recebido do cliente ou do proxy, que vai para todos os logs.
+const maxRequestIDLength = 128
+
+// Middleware identifica a requisição pelo X-Request-ID recebido (ou por um ID gerado, se ele
+// faltar ou for inválido), devolvido no cabeçalho da resposta e incluído nos problemas, coloca
+// no contexto o logger da requisição (FromContext) e, ao final, escreve o log de acesso. Deve
+// ser o primeiro middleware, para que as requisições recusadas também sejam registradas.
+func Middleware(logger *logrus.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(problem.RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			r.Header.Set(problem.RequestIDHeader, id)
+			w.Header().Set(problem.RequestIDHeader, id)
+
+			ctx, rl := withRequestLog(r.Context(), logger.WithField("request_id", id))
+			rec := httpx.NewStatusRecorder(w)
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			rl.current().WithFields(logrus.Fields{
+				"method":     r.Method,
+				"route":      httpx.RouteOrPath(r),
+				"path":       r.URL.Path,
+				"status":     rec.Status,
+				"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+				"bytes":      rec.Bytes,
+			}).Info("Request completed")
+		})
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,239 @@
+package logging
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// Redacao é o tratamento dos dados pessoais (LGPD) nos logs.
+type Redacao string
+
+const (
+	// RedacaoNenhuma registra os dados pessoais como estão; serve apenas ao desenvolvimento local.
+	RedacaoNenhuma Redacao = "nenhuma"
+	// RedacaoHash substitui cada dado pessoal por um HMAC truncado: o valor não aparece, mas o
+	// mesmo valor gera o mesmo hash, o que permite correlacionar os logs.
+	RedacaoHash Redacao = "hash"
+	// RedacaoMascara substitui os dados pessoais por "***".
+	RedacaoMascara Redacao = "mascara"
+)
+
+const mascara = "***"
+
+// ConfigRedacao define o tratamento dos dados pessoais e a chave do HMAC de RedacaoHash.
+type ConfigRedacao struct {
+	Redacao Redacao
+	Chave   []byte
+}
+
+// LoadConfigRedacao lê REDACAO_PII (nenhuma, hash ou mascara; o padrão é mascara) e
+// REDACAO_PII_CHAVE, a chave do HMAC. Sem chave, é gerada uma aleatória, e os hashes só
+// correlacionam os logs do mesmo processo.
+func LoadConfigRedacao() (ConfigRedacao, error) {
+	c := ConfigRedacao{Redacao: RedacaoMascara, Chave: []byte(os.Getenv("REDACAO_PII_CHAVE"))}
+	if v := os.Getenv("REDACAO_PII"); v != "" {
+		c.Redacao = Redacao(v)
+	}
+	switch c.Redacao {
+	case RedacaoNenhuma, RedacaoHash, RedacaoMascara:
+	default:
+		return c, fmt.Errorf("REDACAO_PII inválida: %q", c.Redacao)
+	}
+	if len(c.Chave) == 0 {
+		c.Chave = make([]byte, 32)
+		if _, err := rand.Read(c.Chave); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+// Sensitive marca um valor avulso (que não é um campo de model com a tag pii) como dado pessoal,
+// para ser tratado como os campos com a tag.
+func Sensitive(value interface{}) interface{} {
+	return sensitive{value}
+}
+
+type sensitive struct {
+	value interface{}
+}
+
+// RedactionHook trata os dados pessoais dos campos dos logs: os valores marcados com Sensitive
+// e os campos de structs com a tag `pii:"true"`, que são registradas como objetos com os nomes
+// dos campos em JSON. As demais structs são registradas como estão.
+type RedactionHook struct {
+	config ConfigRedacao
+}
+
+func NewRedactionHook(config ConfigRedacao) *RedactionHook {
+	return &RedactionHook{config}
+}
+
+func (h *RedactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire altera os campos da entrada que será escrita; o logrus entrega aos hooks uma cópia dos
+// campos, então o logger da requisição não é alterado.
+func (h *RedactionHook) Fire(entry *logrus.Entry) error {
+	for key, value := range entry.Data {
+		entry.Data[key] = h.redact(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func (h *RedactionHook) redact(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		if s, ok := v.Interface().(sensitive); ok {
+			return h.pii(reflect.ValueOf(s.value))
+		}
+	}
+	if !hasPII(v.Type()) {
+		return readable(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return h.redact(v.Elem())
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = h.redact(v.Index(i))
+		}
+		return items
+	default:
+		fields := map[string]interface{}{}
+		h.redactStruct(v, fields)
+		return fields
+	}
+}
+
+// redactStruct copia os campos exportados da struct para fields, com os nomes do JSON. Os
+// campos de structs embutidas, por valor ou por ponteiro e mesmo de tipos não exportados, são
+// copiados como se fossem da própria struct, como no JSON.
+func (h *RedactionHook) redactStruct(v reflect.Value, fields map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if embedded, ok := embeddedStruct(f, v.Field(i)); ok {
+			if embedded.IsValid() {
+				h.redactStruct(embedded, fields)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if f.Tag.Get("pii") == "true" {
+			fields[name] = h.pii(v.Field(i))
+		} else {
+			fields[name] = h.redact(v.Field(i))
+		}
+	}
+}
+
+// embeddedStruct retorna a struct embutida no campo f, ou um valor inválido se ela for um
+// ponteiro nulo. ok é falso se o campo não for uma struct embutida sem nome no JSON.
+func embeddedStruct(f reflect.StructField, v reflect.Value) (reflect.Value, bool) {
+	if !f.Anonymous || f.Tag.Get("json") != "" {
+		return reflect.Value{}, false
+	}
+	switch {
+	case f.Type.Kind() == reflect.Struct:
+		return v, true
+	case f.Type.Kind() == reflect.Pointer && f.Type.Elem().Kind() == reflect.Struct:
+		if v.IsNil() {
+			return reflect.Value{}, true
+		}
+		return v.Elem(), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// pii trata um dado pessoal conforme a configuração. Valores nulos continuam nulos.
+func (h *RedactionHook) pii(v reflect.Value) interface{} {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	switch h.config.Redacao {
+	case RedacaoNenhuma:
+		return readable(v)
+	case RedacaoHash:
+		mac := hmac.New(sha256.New, h.config.Chave)
+		fmt.Fprint(mac, readable(v))
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	default:
+		return mascara
+	}
+}
+
+// readable retorna o valor para o log. Os campos promovidos de structs embutidas de tipos não
+// exportados não podem ser lidos com Interface, e são registrados como texto.
+func readable(v reflect.Value) interface{} {
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprint(v)
+}
+
+var piiTypes sync.Map // reflect.Type -> bool
+
+// hasPII informa se valores do tipo podem conter campos com a tag pii.
+func hasPII(t reflect.Type) bool {
+	if cached, ok := piiTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	result := typeHasPII(t, map[reflect.Type]bool{})
+	piiTypes.Store(t, result)
+	return result
+}
+
+// typeHasPII percorre o tipo e os tipos dos seus campos. Um tipo já em visita (tipos recursivos)
+// não acrescenta nada ao resultado; por isso só o resultado do tipo inicial é guardado em cache.
+func typeHasPII(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return typeHasPII(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if (f.IsExported() || f.Anonymous) && (f.Tag.Get("pii") == "true" || typeHasPII(f.Type, visiting)) {
+				return true
+			}
+		}
+	}
+	return false
+}
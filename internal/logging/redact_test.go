@@ -0,0 +1,240 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// Tipos com a tag pii em structs embutidas, aninhadas e em listas.
+type (
+	Pessoa struct {
+		Nome  string `json:"nome" pii:"true"`
+		Email string `pii:"true"`
+	}
+	matricula struct {
+		Pessoa
+		ID      int    `json:"id"`
+		Interno string `json:"-" pii:"true"`
+	}
+	matriculaPonteiro struct {
+		*Pessoa
+		ID int `json:"id"`
+	}
+	matriculaNomeada struct {
+		Pessoa `json:"pessoa"`
+		ID     int `json:"id"`
+	}
+	pessoaOculta struct {
+		Nome  string `json:"nome" pii:"true"`
+		Turma string `json:"turma"`
+	}
+	matriculaOculta struct {
+		pessoaOculta
+		ID int `json:"id"`
+	}
+	turma struct {
+		Nome    string      `json:"nome"`
+		Alunos  []matricula `json:"alunos"`
+		Monitor *Pessoa     `json:"monitor"`
+	}
+	no struct {
+		Nome  string `json:"nome" pii:"true"`
+		Filho *no    `json:"filho"`
+	}
+	// escola e diretor são mutuamente recursivos, e só diretor tem pii diretamente
+	escola struct {
+		Diretor *diretor `json:"diretor"`
+	}
+	diretor struct {
+		Escola *escola `json:"escola"`
+		Nome   string  `json:"nome" pii:"true"`
+	}
+	semPII struct {
+		ID int `json:"id"`
+	}
+)
+
+func redacted(t *testing.T, redacao Redacao, value interface{}) interface{} {
+	t.Helper()
+	h := NewRedactionHook(ConfigRedacao{Redacao: redacao, Chave: []byte("chave")})
+	entry := &logrus.Entry{Data: logrus.Fields{"valor": value}}
+	if err := h.Fire(entry); err != nil {
+		t.Fatal(err)
+	}
+	return entry.Data["valor"]
+}
+
+func TestRedactionHookMascara(t *testing.T) {
+	media, situacao := 7.5, models.SituacaoAprovado
+	professorID := 3
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{
+			name: "aluno",
+			value: models.Aluno{ID: 1, Nome: "Ana", Idade: 15, ProfessorID: &professorID, NomeProfessor: "Silva",
+				NumeroSala: 101, Media: &media, Situacao: &situacao, Version: 2},
+			want: map[string]interface{}{
+				"id": 1, "nome": mascara, "idade": mascara, "professor_id": &professorID, "nome_professor": mascara,
+				"numero_sala": 101, "media": mascara, "situacao": mascara, "situacao_label": nil, "version": 2,
+				"created_at": models.Aluno{}.CreatedAt, "updated_at": models.Aluno{}.UpdatedAt, "deleted_at": (*struct{})(nil),
+			},
+		},
+		{
+			name:  "struct embutida, campo sem tag json e campo omitido do json",
+			value: matricula{Pessoa: Pessoa{Nome: "Ana", Email: "ana@exemplo.com"}, ID: 1, Interno: "segredo"},
+			want:  map[string]interface{}{"nome": mascara, "Email": mascara, "id": 1},
+		},
+		{
+			name:  "struct embutida por ponteiro",
+			value: matriculaPonteiro{Pessoa: &Pessoa{Nome: "Ana", Email: "ana@exemplo.com"}, ID: 1},
+			want:  map[string]interface{}{"nome": mascara, "Email": mascara, "id": 1},
+		},
+		{
+			name:  "struct embutida por ponteiro nulo",
+			value: matriculaPonteiro{ID: 1},
+			want:  map[string]interface{}{"id": 1},
+		},
+		{
+			name:  "struct embutida com nome no json",
+			value: matriculaNomeada{Pessoa: Pessoa{Nome: "Ana"}, ID: 1},
+			want:  map[string]interface{}{"pessoa": map[string]interface{}{"nome": mascara, "Email": mascara}, "id": 1},
+		},
+		{
+			name:  "struct embutida de tipo não exportado",
+			value: matriculaOculta{pessoaOculta: pessoaOculta{Nome: "Ana", Turma: "1º A"}, ID: 1},
+			want:  map[string]interface{}{"nome": mascara, "turma": "1º A", "id": 1},
+		},
+		{
+			name:  "tipos mutuamente recursivos",
+			value: escola{Diretor: &diretor{Nome: "Ana"}},
+			want:  map[string]interface{}{"diretor": map[string]interface{}{"escola": nil, "nome": mascara}},
+		},
+		{
+			name: "structs aninhadas e listas",
+			value: &turma{Nome: "1º A", Alunos: []matricula{{Pessoa: Pessoa{Nome: "Ana"}, ID: 1}, {Pessoa: Pessoa{Nome: "Bia"}, ID: 2}},
+				Monitor: &Pessoa{Nome: "Caio"}},
+			want: map[string]interface{}{
+				"nome": "1º A",
+				"alunos": []interface{}{
+					map[string]interface{}{"nome": mascara, "Email": mascara, "id": 1},
+					map[string]interface{}{"nome": mascara, "Email": mascara, "id": 2},
+				},
+				"monitor": map[string]interface{}{"nome": mascara, "Email": mascara},
+			},
+		},
+		{
+			name:  "tipo recursivo",
+			value: no{Nome: "Ana", Filho: &no{Nome: "Bia"}},
+			want:  map[string]interface{}{"nome": mascara, "filho": map[string]interface{}{"nome": mascara, "filho": nil}},
+		},
+		{name: "ponteiro nulo", value: (*models.Aluno)(nil), want: nil},
+		{name: "struct sem pii", value: semPII{ID: 1}, want: semPII{ID: 1}},
+		{name: "valor comum", value: "GET /alunos", want: "GET /alunos"},
+		{name: "Sensitive", value: Sensitive("nome=Ana"), want: mascara},
+		{name: "Sensitive de ponteiro", value: Sensitive(&situacao), want: mascara},
+		{name: "Sensitive nulo", value: Sensitive((*string)(nil)), want: nil},
+		{name: "Sensitive de struct", value: Sensitive(semPII{ID: 1}), want: mascara},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redacted(t, RedacaoMascara, tt.value)
+			if !reflect.DeepEqual(normalize(got), normalize(tt.want)) {
+				t.Errorf("redacted = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// normalize compara os valores pela representação JSON, que iguala ponteiros nulos de tipos diferentes.
+func normalize(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+func TestRedactionHookHash(t *testing.T) {
+	hash := func(chave string, value interface{}) interface{} {
+		h := NewRedactionHook(ConfigRedacao{Redacao: RedacaoHash, Chave: []byte(chave)})
+		entry := &logrus.Entry{Data: logrus.Fields{"valor": value}}
+		h.Fire(entry)
+		return entry.Data["valor"]
+	}
+
+	ana := hash("chave", Sensitive("Ana"))
+	s, ok := ana.(string)
+	if !ok || !strings.HasPrefix(s, "hmac:") || len(s) != len("hmac:")+16 || strings.Contains(s, "Ana") {
+		t.Fatalf("hash = %#v, want hmac: followed by 16 hex digits", ana)
+	}
+	if again := hash("chave", Sensitive("Ana")); again != ana {
+		t.Errorf("same value hashed to %v and %v", ana, again)
+	}
+	if campo := hash("chave", Pessoa{Nome: "Ana"}).(map[string]interface{})["nome"]; campo != ana {
+		t.Errorf("pii field hashed to %v, want %v, the same as Sensitive", campo, ana)
+	}
+	if campo := hash("chave", matriculaOculta{pessoaOculta: pessoaOculta{Nome: "Ana"}}).(map[string]interface{})["nome"]; campo != ana {
+		t.Errorf("promoted pii field hashed to %v, want %v", campo, ana)
+	}
+	if outro := hash("chave", Sensitive("Bia")); outro == ana {
+		t.Errorf("different values hashed to the same %v", ana)
+	}
+	if outraChave := hash("outra chave", Sensitive("Ana")); outraChave == ana {
+		t.Errorf("different keys hashed to the same %v", ana)
+	}
+}
+
+func TestRedactionHookNenhuma(t *testing.T) {
+	got := redacted(t, RedacaoNenhuma, matricula{Pessoa: Pessoa{Nome: "Ana"}, ID: 1})
+	want := map[string]interface{}{"nome": "Ana", "Email": "", "id": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redacted = %#v, want %#v", got, want)
+	}
+	got = redacted(t, RedacaoNenhuma, matriculaOculta{pessoaOculta: pessoaOculta{Nome: "Ana", Turma: "1º A"}, ID: 1})
+	want = map[string]interface{}{"nome": "Ana", "turma": "1º A", "id": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redacted = %#v, want %#v", got, want)
+	}
+	if got := redacted(t, RedacaoNenhuma, Sensitive("nome=Ana")); got != "nome=Ana" {
+		t.Errorf("Sensitive = %#v, want the value itself", got)
+	}
+}
+
+func TestRedactionHookLogger(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(NewRedactionHook(ConfigRedacao{Redacao: RedacaoMascara}))
+
+	aluno := &models.Aluno{ID: 7, Nome: "Zuleica", Idade: 15}
+	entry := logger.WithField("aluno", aluno).WithField("q", Sensitive("Zuleica"))
+	entry.Info("Created student")
+
+	if strings.Contains(out.String(), "Zuleica") || strings.Contains(out.String(), `"idade":15`) {
+		t.Errorf("log contains personal data: %s", out.String())
+	}
+	var logged struct {
+		Aluno map[string]interface{} `json:"aluno"`
+		Q     string                 `json:"q"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &logged); err != nil {
+		t.Fatalf("decoding %s: %v", out.String(), err)
+	}
+	if logged.Aluno["id"] != float64(7) || logged.Aluno["nome"] != mascara || logged.Q != mascara {
+		t.Errorf("logged = %+v", logged)
+	}
+	if entry.Data["aluno"] != aluno {
+		t.Error("the hook changed the fields of the request logger")
+	}
+}
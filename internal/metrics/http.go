@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/httpx"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute é o rótulo route das requisições que não correspondem a nenhuma rota. O
+// caminho não é usado no rótulo para que caminhos arbitrários não criem séries sem limite.
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total de requisições HTTP atendidas, por método, template da rota e status.",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duração das requisições HTTP, por método e template da rota.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Requisições HTTP em andamento.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequests, httpDuration, httpInFlight)
+}
+
+// Middleware mede as requisições por template da rota (como /alunos/{id}). Deve vir logo após
+// o log de acesso, para que as requisições recusadas pela autenticação e pelo limite de
+// requisições também sejam medidas.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+
+		rec := httpx.NewStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		route := routeTemplate(r)
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(rec.Status)).Inc()
+		httpDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+func routeTemplate(r *http.Request) string {
+	if template, ok := httpx.RouteTemplate(r); ok {
+		return template
+	}
+	return unmatchedRoute
+}
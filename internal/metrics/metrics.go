@@ -0,0 +1,53 @@
+// Package metrics publica as métricas da API para o Prometheus, com o client_golang: as
+// métricas são registradas no registro padrão (prometheus.DefaultRegisterer), que também
+// inclui as métricas do runtime do Go e do processo.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// EnderecoPadrao é o endereço interno das métricas fora de produção, quando nem
+// METRICAS_ENDERECO nem METRICAS_TOKEN são configurados.
+const EnderecoPadrao = "localhost:9090"
+
+// Config define onde as métricas são publicadas. Com Endereco, GET /metrics é servido apenas
+// nesse endereço interno, fora do roteador da API; sem ele, é servido na porta da API e exige
+// o Token. As métricas nunca ficam públicas sem uma das duas proteções.
+type Config struct {
+	Endereco string
+	Token    string
+}
+
+// LoadConfig lê METRICAS_ENDERECO (como localhost:9090 ou :9090) e METRICAS_TOKEN. Em produção
+// (ENV=production), uma das duas é obrigatória; nos demais ambientes, sem nenhuma delas, as
+// métricas são publicadas em EnderecoPadrao.
+func LoadConfig() (Config, error) {
+	c := Config{
+		Endereco: os.Getenv("METRICAS_ENDERECO"),
+		Token:    os.Getenv("METRICAS_TOKEN"),
+	}
+	if c.Endereco == "" && c.Token == "" {
+		if os.Getenv("ENV") == "production" {
+			return c, errors.New("METRICAS_ENDERECO ou METRICAS_TOKEN é obrigatório em produção")
+		}
+		c.Endereco = EnderecoPadrao
+	}
+	return c, nil
+}
+
+// Handler publica o registro padrão no formato de exposição do Prometheus. Uma falha na coleta
+// (como o banco indisponível) omite apenas as métricas afetadas e é registrada no logger.
+func Handler(logger *logrus.Logger) http.Handler {
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+			ErrorLog:      logger,
+			ErrorHandling: promhttp.ContinueOnError,
+		}))
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	logrus "github.com/sirupsen/logrus"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		endereco string
+		token    string
+		want     Config
+		wantErr  bool
+	}{
+		{name: "desenvolvimento sem configuração", want: Config{Endereco: EnderecoPadrao}},
+		{name: "produção sem configuração", env: "production", wantErr: true},
+		{name: "produção com token", env: "production", token: "segredo", want: Config{Token: "segredo"}},
+		{name: "produção com endereço", env: "production", endereco: ":9090", want: Config{Endereco: ":9090"}},
+		{
+			name: "endereço e token", env: "production", endereco: "10.0.0.5:9090", token: "segredo",
+			want: Config{Endereco: "10.0.0.5:9090", Token: "segredo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENV", tt.env)
+			t.Setenv("METRICAS_ENDERECO", tt.endereco)
+			t.Setenv("METRICAS_TOKEN", tt.token)
+
+			got, err := LoadConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("config = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/alunos/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if got := testutil.ToFloat64(httpInFlight); got != 1 {
+			t.Errorf("http_requests_in_flight = %v during the request, want 1", got)
+		}
+		w.WriteHeader(http.StatusTeapot)
+	})
+	router.Use(Middleware)
+	router.NotFoundHandler = Middleware(http.NotFoundHandler())
+
+	requests := func(route, status string) float64 {
+		return testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, route, status))
+	}
+	antes, antesNaoEncontradas := requests("/alunos/{id}", "418"), requests(unmatchedRoute, "404")
+	for _, target := range []string{"/alunos/1", "/alunos/2", "/nao-existe/123"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	if got := requests("/alunos/{id}", "418") - antes; got != 2 {
+		t.Errorf("requests for /alunos/{id} = %v, want 2", got)
+	}
+	if got := requests(unmatchedRoute, "404") - antesNaoEncontradas; got != 1 {
+		t.Errorf("unmatched requests = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(httpInFlight); got != 0 {
+		t.Errorf("http_requests_in_flight = %v after the requests, want 0", got)
+	}
+	if n := testutil.CollectAndCount(httpDuration, "http_request_duration_seconds"); n == 0 {
+		t.Error("http_request_duration_seconds has no series")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	httpRequests.WithLabelValues(http.MethodGet, "/health", "200").Inc()
+
+	rec := httptest.NewRecorder()
+	Handler(logrus.New()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want the text exposition format", ct)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/health",status="200"}`,
+		"# TYPE http_request_duration_seconds histogram",
+		"http_requests_in_flight 0",
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+}
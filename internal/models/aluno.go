@@ -1,16 +1,47 @@
 package models
 
-type ErrorResponse struct {
-	Message string `json:"message"`
-	Code    int    `json:"code"`
-}
+import "time"
+
+// Situações possíveis do aluno, calculadas a partir da média.
+const (
+	SituacaoAprovado    = "aprovado"
+	SituacaoRecuperacao = "recuperacao"
+	SituacaoReprovado   = "reprovado"
+)
 
+// Aluno representa um aluno. Media e Situacao são calculadas pelo serviço a partir das notas
+// (nulas enquanto o aluno não tiver notas) e são ignoradas na escrita. SituacaoLabel é o nome
+// da situação no idioma da resposta. Version é incrementada a cada alteração e também é
+// ignorada na escrita: a versão esperada vem do cabeçalho If-Match. As datas são mantidas pelo
+// banco; DeletedAt só é preenchida nos alunos removidos, que continuam disponíveis para consulta
+// e restauração até serem expurgados. Os campos com a tag pii são dados pessoais, que não vão
+// para os logs (ver logging.RedactionHook).
 type Aluno struct {
-	ID                   int     `json:"id"`
-	Nome                 string  `json:"nome"`
-	Idade                int     `json:"idade"`
-	NotaPrimeiroSemestre float64 `json:"nota_primeiro_semestre"`
-	NotaSegundoSemestre  float64 `json:"nota_segundo_semestre"`
-	NomeProfessor        string  `json:"nome_professor"`
-	NumeroSala           int     `json:"numero_sala"`
+	ID            int        `json:"id"`
+	Nome          string     `json:"nome" maxLength:"100" pii:"true"`
+	Idade         int        `json:"idade" minimum:"1" maximum:"120" pii:"true"`
+	ProfessorID   *int       `json:"professor_id"`
+	NomeProfessor string     `json:"nome_professor" maxLength:"100" pii:"true"`
+	NumeroSala    int        `json:"numero_sala"`
+	Media         *float64   `json:"media" pii:"true"`
+	Situacao      *string    `json:"situacao" pii:"true"`
+	SituacaoLabel *string    `json:"situacao_label" pii:"true"`
+	Version       int        `json:"version"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at"`
+}
+
+// AnyVersion, como versão esperada, dispensa a verificação de versão (If-Match: *).
+const AnyVersion = 0
+
+// AlunoPatch contém apenas os campos enviados em uma atualização parcial; campos nil não são alterados.
+// ClearProfessor desassocia o professor (professor_id: null).
+type AlunoPatch struct {
+	Nome           *string
+	Idade          *int
+	NumeroSala     *int
+	ProfessorID    *int
+	NomeProfessor  *string
+	ClearProfessor bool
 }
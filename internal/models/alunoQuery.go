@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+// AlunoQuery descreve os filtros, a ordenação e a paginação de uma listagem de alunos.
+// Campos nil/vazios não filtram. Cursor e Offset são mutuamente exclusivos. IncludeDeleted
+// inclui os alunos removidos que ainda não foram expurgados. Com AsOf, a listagem mostra os
+// alunos como estavam no instante informado. IDs, quando não é nil, restringe a listagem aos
+// alunos informados (o escopo de acesso do usuário).
+type AlunoQuery struct {
+	Limit  int
+	Offset int
+	Cursor string
+	Sort   string
+	Desc   bool
+
+	IncludeDeleted bool
+	AsOf           *time.Time
+	IDs            []int
+
+	ProfessorID   *int
+	NomeProfessor string
+	NumeroSala    *int
+	IdadeMin      *int
+	IdadeMax      *int
+	Situacao      string
+	MediaMin      *float64
+	MediaMax      *float64
+}
+
+type PageMeta struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type AlunoPage struct {
+	Data []Aluno  `json:"data"`
+	Meta PageMeta `json:"meta"`
+}
+
+// AlunoSearchResult é um aluno encontrado pela busca textual, com a relevância do resultado
+// e os campos em que houve correspondência destacados. Os destaques são HTML: o texto vem
+// escapado e as correspondências ficam entre <mark></mark>.
+type AlunoSearchResult struct {
+	Aluno
+	Relevancia float64           `json:"relevancia"`
+	Destaques  map[string]string `json:"destaques"`
+}
+
+// AlunoChanges são as alterações de alunos desde um token de sincronização, na ordem em que
+// aconteceram. Enquanto HasMore for verdadeiro, o cliente deve pedir a próxima página com
+// NextToken; ao final, guarda NextToken para a próxima sincronização.
+type AlunoChanges struct {
+	Created   []Aluno        `json:"created"`
+	Updated   []Aluno        `json:"updated"`
+	Deleted   []AlunoRemoval `json:"deleted"`
+	NextToken string         `json:"next_token"`
+	HasMore   bool           `json:"has_more"`
+}
+
+// AlunoRemoval identifica um aluno removido. DeletedAt é a data da remoção ou, se o aluno já
+// foi expurgado, a do expurgo.
+type AlunoRemoval struct {
+	ID        int       `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
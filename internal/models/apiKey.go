@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Escopos das chaves de API. alunos:write inclui a leitura.
+const (
+	ScopeAlunosRead  = "alunos:read"
+	ScopeAlunosWrite = "alunos:write"
+)
+
+// APIKey é uma chave de API de integração. A chave em si não é armazenada, apenas o hash;
+// Prefixo é o início dela, para identificação. Sem ExpiresAt, a chave vale até ser revogada.
+type APIKey struct {
+	ID         int        `json:"id"`
+	Nome       string     `json:"nome"`
+	Prefixo    string     `json:"prefixo" example:"dcc_Xy3k9Q"`
+	Scopes     []string   `json:"scopes" example:"alunos:read"`
+	CriadaPor  string     `json:"criada_por"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// NovaAPIKey são os dados de criação de uma chave de API.
+type NovaAPIKey struct {
+	Nome      string     `json:"nome" maxLength:"100"`
+	Scopes    []string   `json:"scopes" example:"alunos:read"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyCriada é a chave recém-criada, com o valor da chave (Key), exibido apenas nesta resposta.
+type APIKeyCriada struct {
+	APIKey
+	Key string `json:"key" example:"dcc_Xy3k9Q..."`
+}
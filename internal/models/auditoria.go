@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Operações registradas na trilha de auditoria dos alunos.
+const (
+	OperacaoCriacao     = "criacao"
+	OperacaoAlteracao   = "alteracao"
+	OperacaoSituacao    = "situacao" // média e situação recalculadas a partir das notas
+	OperacaoRemocao     = "remocao"
+	OperacaoRestauracao = "restauracao"
+	OperacaoExpurgo     = "expurgo"
+)
+
+// Alteracao é o valor de um campo antes e depois de uma operação (null quando o campo não existia).
+type Alteracao struct {
+	Antes  json.RawMessage `json:"antes" swaggertype:"object"`
+	Depois json.RawMessage `json:"depois" swaggertype:"object"`
+}
+
+// Auditoria é uma entrada da trilha de auditoria: quem fez qual operação em um aluno, quando,
+// e os campos alterados, com os nomes das colunas da tabela alunos. A trilha cobre apenas os
+// dados do aluno: as notas só aparecem pelo efeito na média e na situação (OperacaoSituacao), e
+// professores, salas e disciplinas não são auditados. Diff fica vazio depois do expurgo.
+type Auditoria struct {
+	ID       int64                `json:"id"`
+	AlunoID  int                  `json:"aluno_id"`
+	Ator     string               `json:"ator"`
+	Operacao string               `json:"operacao"`
+	Data     time.Time            `json:"data"`
+	Diff     map[string]Alteracao `json:"diff"`
+}
+
+// AuditoriaQuery descreve os filtros e a paginação da consulta à trilha de auditoria.
+// Campos nil/vazios não filtram. O intervalo [Desde, Ate) inclui Desde e exclui Ate.
+type AuditoriaQuery struct {
+	Limit  int
+	Offset int
+
+	AlunoID  *int
+	Ator     string
+	Operacao string
+	Desde    *time.Time
+	Ate      *time.Time
+}
+
+// AuditoriaPage são as entradas da trilha, da mais recente para a mais antiga.
+type AuditoriaPage struct {
+	Data []Auditoria `json:"data"`
+	Meta PageMeta    `json:"meta"`
+}
@@ -0,0 +1,19 @@
+package models
+
+type Disciplina struct {
+	ID   int    `json:"id"`
+	Nome string `json:"nome"`
+}
+
+// Avaliacao é a nota de um aluno em uma avaliação (prova, trabalho...) de uma disciplina em um bimestre.
+// Recuperacao marca as provas de recuperação, tratadas de forma especial por algumas políticas de média.
+type Avaliacao struct {
+	ID             int     `json:"id"`
+	AlunoID        int     `json:"aluno_id"`
+	DisciplinaID   int     `json:"disciplina_id"`
+	NomeDisciplina string  `json:"nome_disciplina"`
+	Bimestre       int     `json:"bimestre" minimum:"1" maximum:"4"`
+	Descricao      string  `json:"descricao" maxLength:"100"`
+	Nota           float64 `json:"nota" minimum:"0" maximum:"10" pii:"true"`
+	Recuperacao    bool    `json:"recuperacao"`
+}
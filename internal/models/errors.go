@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+)
+
+// Categorias dos erros de domínio. Cada erro específico abaixo pertence a uma delas
+// (errors.Is(ErrAlunoNotFound, ErrNotFound) é verdadeiro), e a camada de handlers escolhe
+// o status HTTP pela categoria, sem precisar conhecer cada erro.
+var (
+	ErrNotFound   = errors.New("recurso não encontrado")
+	ErrConflict   = errors.New("conflito com o estado atual do recurso")
+	ErrValidation = errors.New("dados inválidos")
+	// ErrPreconditionFailed indica que o recurso não está mais na versão esperada pelo cliente.
+	ErrPreconditionFailed = errors.New("o recurso foi alterado")
+	// ErrForbidden indica que o usuário autenticado não tem permissão para a operação.
+	ErrForbidden = errors.New("acesso negado")
+)
+
+// ErrInvalidQuery identifica parâmetros de consulta (query string) inválidos.
+var ErrInvalidQuery = errors.New("consulta inválida")
+
+// domainError é um erro de domínio com mensagem do catálogo, pertencente a uma categoria.
+type domainError struct {
+	kind    error
+	message i18n.Message
+}
+
+func (e *domainError) Error() string { return e.message.In(i18n.Default) }
+
+func (e *domainError) Localize(lang i18n.Lang) string { return e.message.In(lang) }
+
+func (e *domainError) Unwrap() error { return e.kind }
+
+func newDomainError(kind error, key string) error {
+	return &domainError{kind, i18n.NewMessage(key)}
+}
+
+// InvalidQuery cria um erro ErrInvalidQuery com a mensagem da chave informada do catálogo.
+func InvalidQuery(key string, args ...interface{}) error {
+	return &domainError{ErrInvalidQuery, i18n.NewMessage(key, args...)}
+}
+
+var (
+	ErrAccessDenied         = newDomainError(ErrForbidden, "error.access_denied")
+	ErrAPIKeyNotFound       = newDomainError(ErrNotFound, "error.api_key_not_found")
+	ErrAlunoNotFound        = newDomainError(ErrNotFound, "error.aluno_not_found")
+	ErrAlunoVersionMismatch = newDomainError(ErrPreconditionFailed, "error.aluno_version_mismatch")
+	ErrProfessorNotFound    = newDomainError(ErrNotFound, "error.professor_not_found")
+	ErrDuplicateProfessor   = newDomainError(ErrConflict, "error.duplicate_professor")
+	ErrSalaNotFound         = newDomainError(ErrNotFound, "error.sala_not_found")
+	ErrDuplicateSala        = newDomainError(ErrConflict, "error.duplicate_sala")
+	ErrSalaInUse            = newDomainError(ErrConflict, "error.sala_in_use")
+	ErrSalaHasRemovedAlunos = newDomainError(ErrConflict, "error.sala_has_removed_alunos")
+	ErrSalaCapacity         = newDomainError(ErrConflict, "error.sala_capacity")
+	ErrSalaFull             = newDomainError(ErrConflict, "error.sala_full")
+	ErrDisciplinaNotFound   = newDomainError(ErrNotFound, "error.disciplina_not_found")
+	ErrDuplicateDisciplina  = newDomainError(ErrConflict, "error.duplicate_disciplina")
+	ErrDisciplinaInUse      = newDomainError(ErrConflict, "error.disciplina_in_use")
+	ErrAvaliacaoNotFound    = newDomainError(ErrNotFound, "error.avaliacao_not_found")
+	ErrDuplicateAvaliacao   = newDomainError(ErrConflict, "error.duplicate_avaliacao")
+)
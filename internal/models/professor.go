@@ -0,0 +1,6 @@
+package models
+
+type Professor struct {
+	ID   int    `json:"id"`
+	Nome string `json:"nome" pii:"true"`
+}
@@ -0,0 +1,9 @@
+package models
+
+type Sala struct {
+	ID         int    `json:"id"`
+	Numero     int    `json:"numero"`
+	Predio     string `json:"predio"`
+	Capacidade int    `json:"capacidade"`
+	Ocupacao   int    `json:"ocupacao"` // alunos matriculados; os removidos não ocupam vaga
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+)
+
+// Códigos de violação retornados em FieldError.Code.
+const (
+	CodeRequired   = "required"
+	CodeMaxLength  = "max_length"
+	CodeOutOfRange = "out_of_range"
+	CodeNotFound   = "not_found"
+	CodeInvalid    = "invalid"
+)
+
+// Limites aceitos na entrada, espelhando as colunas e restrições do banco.
+const (
+	MaxNomeLength      = 100
+	MaxDescricaoLength = 100
+	MaxPredioLength    = 100
+	IdadeMinima        = 1
+	IdadeMaxima        = 120
+	NotaMinima         = 0.0
+	NotaMaxima         = 10.0
+	BimestreMinimo     = 1
+	BimestreMaximo     = 4
+)
+
+// FieldError descreve a violação de uma regra de validação em um campo da requisição.
+// Message vem no idioma padrão; Localize a traduz para o idioma da resposta.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	message i18n.Message
+}
+
+// NewFieldError cria a violação com a mensagem da chave informada do catálogo.
+func NewFieldError(field, code, key string, args ...interface{}) FieldError {
+	message := i18n.NewMessage(key, args...)
+	return FieldError{Field: field, Code: code, Message: message.In(i18n.Default), message: message}
+}
+
+func (fe FieldError) Localize(lang i18n.Lang) FieldError {
+	if fe.message.Key != "" {
+		fe.Message = fe.message.In(lang)
+	}
+	return fe
+}
+
+// ValidationError é retornado pelos serviços quando a entrada viola uma ou mais regras de validação.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	mensagens := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		mensagens[i] = fe.Field + ": " + fe.Message
+	}
+	return "dados inválidos: " + strings.Join(mensagens, "; ")
+}
+
+func (e *ValidationError) Unwrap() error { return ErrValidation }
@@ -0,0 +1,200 @@
+// Package policy decide o que cada usuário autenticado pode fazer, pelos papéis do token e
+// pelo vínculo dele com o aluno. As regras são aplicadas pelos serviços, e não apenas nas rotas,
+// para valerem em qualquer caminho que chegue a eles.
+package policy
+
+import (
+	"context"
+	"slices"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/actor"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/auth"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+// Papéis reconhecidos na claim roles do token.
+const (
+	RoleAdmin       = "admin"
+	RoleSecretaria  = "secretaria"
+	RoleProfessor   = "professor"
+	RoleResponsavel = "responsavel"
+)
+
+// Action é uma operação sujeita a autorização.
+type Action string
+
+const (
+	// LerAlunos consulta alunos e notas.
+	LerAlunos Action = "alunos:ler"
+	// LerAlunosRemovidos consulta alunos removidos ainda não expurgados.
+	LerAlunosRemovidos Action = "alunos:ler_removidos"
+	// SincronizarAlunos consulta o feed de alterações de alunos.
+	SincronizarAlunos Action = "alunos:sincronizar"
+	// MatricularAlunos cria, altera, remove e restaura alunos.
+	MatricularAlunos Action = "alunos:matricular"
+	// RecalcularSituacoes recalcula a situação de todos os alunos.
+	RecalcularSituacoes Action = "alunos:recalcular"
+	// LancarNotas lança, altera e remove notas.
+	LancarNotas Action = "notas:lancar"
+	// LerHistorico consulta o histórico de alterações de um aluno.
+	LerHistorico Action = "auditoria:historico"
+	// LerAuditoria consulta a trilha de auditoria completa.
+	LerAuditoria Action = "auditoria:ler"
+	// GerenciarCadastros cria, altera e remove professores, salas e disciplinas.
+	GerenciarCadastros Action = "cadastros:gerenciar"
+	// GerenciarAPIKeys cria, lista e revoga chaves de API.
+	GerenciarAPIKeys Action = "api_keys:gerenciar"
+)
+
+// escopo restringe uma permissão aos alunos vinculados ao usuário.
+type escopo int
+
+const (
+	todos       escopo = iota
+	seusAlunos         // alunos cujo professor é o usuário
+	dependentes        // alunos dos quais o usuário é responsável
+)
+
+// regras lista, para cada papel, as ações permitidas e o escopo de alunos de cada uma. O admin
+// pode tudo e não aparece aqui.
+var regras = map[string]map[Action]escopo{
+	RoleSecretaria: {
+		LerAlunos:          todos,
+		SincronizarAlunos:  todos,
+		MatricularAlunos:   todos,
+		LerHistorico:       todos,
+		GerenciarCadastros: todos,
+	},
+	RoleProfessor: {
+		LerAlunos:         todos,
+		SincronizarAlunos: todos,
+		LancarNotas:       seusAlunos,
+	},
+	RoleResponsavel: {
+		LerAlunos: dependentes,
+	},
+}
+
+// regrasEscopos são as ações permitidas pelos escopos das chaves de API.
+var regrasEscopos = map[string]map[Action]escopo{
+	models.ScopeAlunosRead: {
+		LerAlunos:         todos,
+		SincronizarAlunos: todos,
+	},
+	models.ScopeAlunosWrite: {
+		LerAlunos:         todos,
+		SincronizarAlunos: todos,
+		MatricularAlunos:  todos,
+		LancarNotas:       todos,
+	},
+}
+
+// Policy autoriza as operações do usuário autenticado no contexto (auth.FromContext). Sem
+// usuário no contexto, nada é permitido.
+type Policy interface {
+	// Authorize verifica se o usuário pode executar a ação em ao menos parte dos alunos; as
+	// ações sobre um aluno específico devem usar AuthorizeAluno.
+	Authorize(ctx context.Context, action Action) error
+	// AuthorizeAluno verifica se o usuário pode executar a ação sobre o aluno informado.
+	AuthorizeAluno(ctx context.Context, action Action, aluno *models.Aluno) error
+	// AlunosVisiveis retorna os IDs dos únicos alunos que o usuário pode consultar, ou nil se
+	// ele pode consultar todos.
+	AlunosVisiveis(ctx context.Context) []int
+}
+
+type rbac struct{}
+
+func NewRBAC() Policy {
+	return rbac{}
+}
+
+// permissoes reúne os escopos com que os papéis (ou os escopos da chave de API) do usuário
+// permitem a ação.
+func permissoes(p *auth.Principal, action Action) []escopo {
+	var escopos []escopo
+	for _, role := range p.Roles {
+		if role == RoleAdmin {
+			return []escopo{todos}
+		}
+		if e, ok := regras[role][action]; ok {
+			escopos = append(escopos, e)
+		}
+	}
+	for _, scope := range p.Scopes {
+		if e, ok := regrasEscopos[scope][action]; ok {
+			escopos = append(escopos, e)
+		}
+	}
+	return escopos
+}
+
+func (rbac) Authorize(ctx context.Context, action Action) error {
+	p, ok := auth.FromContext(ctx)
+	if !ok || len(permissoes(p, action)) == 0 {
+		return denied(ctx, action, nil)
+	}
+	return nil
+}
+
+func (rbac) AuthorizeAluno(ctx context.Context, action Action, aluno *models.Aluno) error {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return denied(ctx, action, aluno)
+	}
+	for _, e := range permissoes(p, action) {
+		if alcanca(p, e, aluno) {
+			return nil
+		}
+	}
+	return denied(ctx, action, aluno)
+}
+
+// denied registra a recusa no log da requisição e retorna o erro de acesso negado.
+func denied(ctx context.Context, action Action, aluno *models.Aluno) error {
+	entry := logging.FromContext(ctx).WithField("action", action)
+	if aluno != nil {
+		entry = entry.WithField("aluno_id", aluno.ID)
+	}
+	entry.Warn("Access denied")
+	return models.ErrAccessDenied
+}
+
+func alcanca(p *auth.Principal, e escopo, aluno *models.Aluno) bool {
+	switch e {
+	case todos:
+		return true
+	case seusAlunos:
+		return p.ProfessorID != nil && aluno.ProfessorID != nil && *p.ProfessorID == *aluno.ProfessorID
+	case dependentes:
+		return slices.Contains(p.AlunoIDs, aluno.ID)
+	default:
+		return false
+	}
+}
+
+func (rbac) AlunosVisiveis(ctx context.Context) []int {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return []int{}
+	}
+	ids := []int{}
+	for _, e := range permissoes(p, LerAlunos) {
+		switch e {
+		case todos:
+			return nil
+		case dependentes:
+			ids = append(ids, p.AlunoIDs...)
+		}
+	}
+	return ids
+}
+
+// System é o usuário das operações executadas pela própria aplicação, como o recálculo das
+// situações na inicialização; ele tem o papel de admin.
+var System = &auth.Principal{Subject: actor.System, Roles: []string{RoleAdmin}}
+
+// SystemContext retorna uma cópia de ctx identificando a própria aplicação como usuário e ator.
+func SystemContext(ctx context.Context) context.Context {
+	return actor.WithActor(auth.WithPrincipal(ctx, System), actor.System)
+}
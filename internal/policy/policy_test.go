@@ -0,0 +1,150 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/auth"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+func intPtr(v int) *int { return &v }
+
+var (
+	admin       = &auth.Principal{Subject: "admin", Roles: []string{RoleAdmin}}
+	secretaria  = &auth.Principal{Subject: "secretaria", Roles: []string{RoleSecretaria}}
+	professor   = &auth.Principal{Subject: "professor", Roles: []string{RoleProfessor}, ProfessorID: intPtr(3)}
+	responsavel = &auth.Principal{Subject: "responsavel", Roles: []string{RoleResponsavel}, AlunoIDs: []int{1, 2}}
+	leitura     = &auth.Principal{Subject: "chave-leitura", Scopes: []string{models.ScopeAlunosRead}}
+	escrita     = &auth.Principal{Subject: "chave-escrita", Scopes: []string{models.ScopeAlunosWrite}}
+	// O responsável que também é professor soma as permissões dos dois papéis
+	professorResponsavel = &auth.Principal{Subject: "professor-responsavel", Roles: []string{RoleProfessor, RoleResponsavel},
+		ProfessorID: intPtr(3), AlunoIDs: []int{1}}
+	semPapel = &auth.Principal{Subject: "sem-papel"}
+)
+
+var (
+	// alunoDoProfessor é dependente do responsável e aluno do professor 3
+	alunoDoProfessor = &models.Aluno{ID: 1, ProfessorID: intPtr(3)}
+	// alunoDeOutro é dependente do responsável, mas aluno do professor 4
+	alunoDeOutro = &models.Aluno{ID: 2, ProfessorID: intPtr(4)}
+	// alunoSemVinculo não é dependente do responsável nem tem professor
+	alunoSemVinculo = &models.Aluno{ID: 5}
+)
+
+func withPrincipal(p *auth.Principal) context.Context {
+	if p == nil {
+		return context.Background()
+	}
+	return auth.WithPrincipal(context.Background(), p)
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		allowed   []Action
+	}{
+		{name: "admin", principal: admin, allowed: []Action{LerAlunos, LerAlunosRemovidos, SincronizarAlunos, MatricularAlunos,
+			RecalcularSituacoes, LancarNotas, LerHistorico, LerAuditoria, GerenciarCadastros, GerenciarAPIKeys}},
+		{name: "secretaria", principal: secretaria, allowed: []Action{LerAlunos, SincronizarAlunos, MatricularAlunos, LerHistorico, GerenciarCadastros}},
+		{name: "professor", principal: professor, allowed: []Action{LerAlunos, SincronizarAlunos, LancarNotas}},
+		{name: "responsável", principal: responsavel, allowed: []Action{LerAlunos}},
+		{name: "chave de leitura", principal: leitura, allowed: []Action{LerAlunos, SincronizarAlunos}},
+		{name: "chave de escrita", principal: escrita, allowed: []Action{LerAlunos, SincronizarAlunos, MatricularAlunos, LancarNotas}},
+		{name: "sem papel", principal: semPapel},
+		{name: "sem usuário"},
+	}
+	actions := []Action{LerAlunos, LerAlunosRemovidos, SincronizarAlunos, MatricularAlunos, RecalcularSituacoes,
+		LancarNotas, LerHistorico, LerAuditoria, GerenciarCadastros, GerenciarAPIKeys}
+	for _, tt := range tests {
+		for _, action := range actions {
+			t.Run(tt.name+"/"+string(action), func(t *testing.T) {
+				err := NewRBAC().Authorize(withPrincipal(tt.principal), action)
+				want := slices.Contains(tt.allowed, action)
+				if want && err != nil {
+					t.Errorf("Authorize(%s) = %v, want allowed", action, err)
+				}
+				if !want && !errors.Is(err, models.ErrAccessDenied) {
+					t.Errorf("Authorize(%s) = %v, want ErrAccessDenied", action, err)
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizeAluno(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		action    Action
+		aluno     *models.Aluno
+		want      bool
+	}{
+		{name: "admin lança notas de qualquer aluno", principal: admin, action: LancarNotas, aluno: alunoSemVinculo, want: true},
+		{name: "secretaria matricula qualquer aluno", principal: secretaria, action: MatricularAlunos, aluno: alunoSemVinculo, want: true},
+		{name: "secretaria não lança notas", principal: secretaria, action: LancarNotas, aluno: alunoDoProfessor},
+
+		{name: "professor lê aluno de outro professor", principal: professor, action: LerAlunos, aluno: alunoDeOutro, want: true},
+		{name: "professor lança notas do seu aluno", principal: professor, action: LancarNotas, aluno: alunoDoProfessor, want: true},
+		{name: "professor não lança notas de aluno de outro professor", principal: professor, action: LancarNotas, aluno: alunoDeOutro},
+		{name: "professor não lança notas de aluno sem professor", principal: professor, action: LancarNotas, aluno: alunoSemVinculo},
+		{name: "professor sem professor_id não lança notas", action: LancarNotas, aluno: alunoDoProfessor,
+			principal: &auth.Principal{Roles: []string{RoleProfessor}}},
+		{name: "professor não matricula o seu aluno", principal: professor, action: MatricularAlunos, aluno: alunoDoProfessor},
+
+		{name: "responsável lê o seu dependente", principal: responsavel, action: LerAlunos, aluno: alunoDoProfessor, want: true},
+		{name: "responsável não lê quem não é dependente", principal: responsavel, action: LerAlunos, aluno: alunoSemVinculo},
+		{name: "responsável não lança notas do dependente", principal: responsavel, action: LancarNotas, aluno: alunoDoProfessor},
+		{name: "responsável não matricula o dependente", principal: responsavel, action: MatricularAlunos, aluno: alunoDoProfessor},
+
+		{name: "professor e responsável lê qualquer aluno", principal: professorResponsavel, action: LerAlunos, aluno: alunoSemVinculo, want: true},
+		{name: "professor e responsável não lança notas do dependente de outro professor", principal: professorResponsavel,
+			action: LancarNotas, aluno: alunoDeOutro},
+
+		{name: "chave de escrita lança notas de qualquer aluno", principal: escrita, action: LancarNotas, aluno: alunoSemVinculo, want: true},
+		{name: "chave de leitura não lança notas", principal: leitura, action: LancarNotas, aluno: alunoDoProfessor},
+		{name: "sem papel", principal: semPapel, action: LerAlunos, aluno: alunoDoProfessor},
+		{name: "sem usuário", action: LerAlunos, aluno: alunoDoProfessor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRBAC().AuthorizeAluno(withPrincipal(tt.principal), tt.action, tt.aluno)
+			if tt.want && err != nil {
+				t.Errorf("AuthorizeAluno(%s, %d) = %v, want allowed", tt.action, tt.aluno.ID, err)
+			}
+			if !tt.want && !errors.Is(err, models.ErrAccessDenied) {
+				t.Errorf("AuthorizeAluno(%s, %d) = %v, want ErrAccessDenied", tt.action, tt.aluno.ID, err)
+			}
+		})
+	}
+}
+
+func TestAlunosVisiveis(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      []int
+	}{
+		{name: "admin", principal: admin},
+		{name: "secretaria", principal: secretaria},
+		{name: "professor", principal: professor},
+		{name: "professor e responsável", principal: professorResponsavel},
+		{name: "chave de leitura", principal: leitura},
+		{name: "responsável", principal: responsavel, want: []int{1, 2}},
+		{name: "responsável sem dependentes", principal: &auth.Principal{Roles: []string{RoleResponsavel}}, want: []int{}},
+		{name: "sem papel", principal: semPapel, want: []int{}},
+		{name: "sem usuário", want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRBAC().AlunosVisiveis(withPrincipal(tt.principal))
+			// nil (todos os alunos) e a lista vazia (nenhum aluno) têm significados opostos
+			if (got == nil) != (tt.want == nil) || !slices.Equal(got, tt.want) {
+				t.Errorf("AlunosVisiveis = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
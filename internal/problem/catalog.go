@@ -0,0 +1,84 @@
+package problem
+
+import (
+	"net/http"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+)
+
+// BasePath é o prefixo das URIs dos tipos de problema.
+const BasePath = "/problems/"
+
+// Type é um tipo de problema do catálogo. A URI identifica o tipo de forma estável: clientes
+// devem decidir pelo campo type (e não pelo title ou detail, que variam com o idioma).
+// As URIs são relativas à raiz da API e podem ser consultadas em GET /problems/{slug}.
+// Título e descrição vêm do catálogo de mensagens (problem.<slug>.title e .description).
+type Type struct {
+	Slug   string
+	Status int
+}
+
+func (t Type) URI() string { return BasePath + t.Slug }
+
+func (t Type) Title(lang i18n.Lang) string {
+	return i18n.T(lang, "problem."+t.Slug+".title")
+}
+
+// Description é um tipo de problema como publicado no catálogo, no idioma da requisição.
+type Description struct {
+	Type        string `json:"type" example:"/problems/not-found"`
+	Title       string `json:"title" example:"Recurso não encontrado"`
+	Status      int    `json:"status" example:"404"`
+	Description string `json:"description" example:"O recurso indicado na URL não existe."`
+}
+
+func (t Type) Describe(lang i18n.Lang) Description {
+	return Description{
+		Type:        t.URI(),
+		Title:       t.Title(lang),
+		Status:      t.Status,
+		Description: i18n.T(lang, "problem."+t.Slug+".description"),
+	}
+}
+
+var (
+	InvalidRequest       = Type{"invalid-request", http.StatusBadRequest}
+	Unauthorized         = Type{"unauthorized", http.StatusUnauthorized}
+	Forbidden            = Type{"forbidden", http.StatusForbidden}
+	NotFound             = Type{"not-found", http.StatusNotFound}
+	MethodNotAllowed     = Type{"method-not-allowed", http.StatusMethodNotAllowed}
+	Conflict             = Type{"conflict", http.StatusConflict}
+	PreconditionFailed   = Type{"precondition-failed", http.StatusPreconditionFailed}
+	UnsupportedMediaType = Type{"unsupported-media-type", http.StatusUnsupportedMediaType}
+	ValidationError      = Type{"validation-error", http.StatusUnprocessableEntity}
+	PreconditionRequired = Type{"precondition-required", http.StatusPreconditionRequired}
+	TooManyRequests      = Type{"too-many-requests", http.StatusTooManyRequests}
+	InternalError        = Type{"internal-error", http.StatusInternalServerError}
+)
+
+// Catalog lista os tipos de problema publicados pela API. URIs publicadas não são removidas
+// nem reaproveitadas para outro significado.
+var Catalog = []Type{
+	InvalidRequest,
+	Unauthorized,
+	Forbidden,
+	NotFound,
+	MethodNotAllowed,
+	Conflict,
+	PreconditionFailed,
+	UnsupportedMediaType,
+	ValidationError,
+	PreconditionRequired,
+	TooManyRequests,
+	InternalError,
+}
+
+// Lookup retorna o tipo do catálogo com o slug informado (a parte final da URI).
+func Lookup(slug string) (Type, bool) {
+	for _, t := range Catalog {
+		if t.Slug == slug {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
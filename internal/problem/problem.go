@@ -0,0 +1,47 @@
+// Package problem implementa as respostas de erro no formato Problem Details for HTTP APIs
+// (RFC 7807, application/problem+json) e o catálogo dos tipos de problema publicados pela API.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+const ContentType = "application/problem+json"
+
+// RequestIDHeader é o cabeçalho de onde vem o ID da requisição incluído nos problemas.
+const RequestIDHeader = "X-Request-ID"
+
+// Problem é o corpo das respostas de erro. RequestID e Errors são membros de extensão:
+// o ID da requisição, para correlação com os logs, e as violações de validação.
+type Problem struct {
+	Type      string              `json:"type" example:"/problems/not-found"`
+	Title     string              `json:"title" example:"Recurso não encontrado"`
+	Status    int                 `json:"status" example:"404"`
+	Detail    string              `json:"detail,omitempty" example:"Aluno não encontrado"`
+	Instance  string              `json:"instance,omitempty" example:"/alunos/42"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []models.FieldError `json:"errors,omitempty"`
+}
+
+// New cria o problema do tipo t ocorrido na requisição r, com o título no idioma da requisição.
+func New(t Type, r *http.Request, detail string) *Problem {
+	return &Problem{
+		Type:      t.URI(),
+		Title:     t.Title(i18n.FromRequest(r)),
+		Status:    t.Status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: r.Header.Get(RequestIDHeader),
+	}
+}
+
+// Write envia o problema como application/problem+json, com o status do seu tipo.
+func Write(w http.ResponseWriter, p *Problem) error {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
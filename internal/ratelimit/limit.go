@@ -0,0 +1,124 @@
+// Package ratelimit limita a taxa de requisições de cada cliente com baldes de tokens (token
+// bucket). O cliente é o usuário autenticado (sujeito do token JWT ou chave de API) ou, nas
+// requisições sem autenticação, o endereço IP.
+package ratelimit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit é a capacidade de um balde: Requests requisições por Period. O balde começa cheio e é
+// reabastecido continuamente, então um cliente parado pode fazer até Requests requisições de
+// uma vez.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// rate é o reabastecimento do balde, em tokens por segundo.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+var periodUnits = map[string]time.Duration{"s": time.Second, "m": time.Minute, "h": time.Hour}
+
+// ParseLimit interpreta um limite no formato "<requisições>/<unidade>", com a unidade s, m ou h
+// (por exemplo, "120/m").
+func ParseLimit(s string) (Limit, error) {
+	n, unit, ok := strings.Cut(strings.TrimSpace(s), "/")
+	requests, err := strconv.Atoi(n)
+	period, known := periodUnits[unit]
+	if !ok || err != nil || requests <= 0 || !known {
+		return Limit{}, fmt.Errorf("limite inválido: %q (use, por exemplo, 120/m)", s)
+	}
+	return Limit{requests, period}, nil
+}
+
+// ClasseAPIKey é a classe, em PorPapel, dos clientes autenticados por chave de API, que não têm papéis.
+const ClasseAPIKey = "api_key"
+
+// Config são os limites aplicados. Toda requisição consome, antes da autenticação, do balde do
+// endereço IP, com o limite PorIP; ele protege a autenticação e acomoda vários usuários atrás
+// do mesmo endereço. Depois, consome do balde geral do cliente, cujo limite é o do papel do
+// cliente em PorPapel (o maior, se ele tiver vários) ou Padrao. As rotas em PorRota,
+// identificadas por "MÉTODO /template" ou apenas "/template" (qualquer método), têm ainda um
+// balde próprio por cliente. ConfiarProxy identifica o cliente pelo último endereço de
+// X-Forwarded-For, o adicionado pelo proxy da plataforma (como o roteador do Heroku).
+type Config struct {
+	Padrao       Limit
+	PorIP        Limit
+	PorPapel     map[string]Limit
+	PorRota      map[string]Limit
+	ConfiarProxy bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Padrao:   Limit{120, time.Minute},
+		PorIP:    Limit{600, time.Minute},
+		PorPapel: map[string]Limit{},
+		PorRota:  map[string]Limit{},
+	}
+}
+
+// LoadConfig lê o limite padrão de LIMITE_REQUISICOES, o limite por IP de LIMITE_REQUISICOES_IP,
+// os limites por papel de LIMITE_REQUISICOES_PAPEIS (no formato "admin=600/m,api_key=300/m"),
+// os limites por rota de LIMITE_REQUISICOES_ROTAS (no formato "GET /alunos=30/m,/alunos/search=60/m")
+// e CONFIAR_X_FORWARDED_FOR, usando os valores padrão para as ausentes.
+func LoadConfig() (Config, error) {
+	c := DefaultConfig()
+
+	single := map[string]*Limit{
+		"LIMITE_REQUISICOES":    &c.Padrao,
+		"LIMITE_REQUISICOES_IP": &c.PorIP,
+	}
+	for name, dest := range single {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		limit, err := ParseLimit(v)
+		if err != nil {
+			return c, fmt.Errorf("%s: %w", name, err)
+		}
+		*dest = limit
+	}
+	lists := map[string]map[string]Limit{
+		"LIMITE_REQUISICOES_PAPEIS": c.PorPapel,
+		"LIMITE_REQUISICOES_ROTAS":  c.PorRota,
+	}
+	for name, dest := range lists {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		for _, item := range strings.Split(v, ",") {
+			key, value, ok := strings.Cut(item, "=")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" {
+				return c, fmt.Errorf("%s inválido: %q", name, item)
+			}
+			limit, err := ParseLimit(value)
+			if err != nil {
+				return c, fmt.Errorf("%s: %w", name, err)
+			}
+			dest[key] = limit
+		}
+	}
+	if v := os.Getenv("CONFIAR_X_FORWARDED_FOR"); v != "" {
+		confiar, err := strconv.ParseBool(v)
+		if err != nil {
+			return c, fmt.Errorf("CONFIAR_X_FORWARDED_FOR inválido: %q", v)
+		}
+		c.ConfiarProxy = confiar
+	}
+	return c, nil
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Limit
+		wantErr bool
+	}{
+		{s: "120/m", want: Limit{120, time.Minute}},
+		{s: " 10/s ", want: Limit{10, time.Second}},
+		{s: "1000/h", want: Limit{1000, time.Hour}},
+		{s: "120", wantErr: true},
+		{s: "0/m", wantErr: true},
+		{s: "-1/m", wantErr: true},
+		{s: "10/d", wantErr: true},
+		{s: "dez/m", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLimit(%q) = %v, %v, want %v (error %v)", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("LIMITE_REQUISICOES", "60/m")
+	t.Setenv("LIMITE_REQUISICOES_IP", "300/m")
+	t.Setenv("LIMITE_REQUISICOES_PAPEIS", "admin=600/m, api_key=300/m")
+	t.Setenv("LIMITE_REQUISICOES_ROTAS", "GET /alunos=30/m")
+	t.Setenv("CONFIAR_X_FORWARDED_FOR", "true")
+
+	c, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if c.Padrao != (Limit{60, time.Minute}) || c.PorIP != (Limit{300, time.Minute}) || !c.ConfiarProxy {
+		t.Errorf("config = %+v", c)
+	}
+	if c.PorPapel["admin"] != (Limit{600, time.Minute}) || c.PorPapel[ClasseAPIKey] != (Limit{300, time.Minute}) {
+		t.Errorf("PorPapel = %v", c.PorPapel)
+	}
+	if c.PorRota["GET /alunos"] != (Limit{30, time.Minute}) {
+		t.Errorf("PorRota = %v", c.PorRota)
+	}
+
+	t.Setenv("LIMITE_REQUISICOES_IP", "muitos")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("LoadConfig accepted an invalid LIMITE_REQUISICOES_IP")
+	}
+}
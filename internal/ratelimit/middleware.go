@@ -0,0 +1,184 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/auth"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/httpx"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// Limiter aplica os limites da configuração com os baldes guardados no store.
+type Limiter struct {
+	config Config
+	store  Store
+}
+
+func NewLimiter(config Config, store Store) *Limiter {
+	return &Limiter{config, store}
+}
+
+// IPMiddleware limita as requisições de cada endereço IP com o limite PorIP. Deve vir antes da
+// autenticação, para que tentativas de credenciais inválidas também sejam limitadas.
+func IPMiddleware(limiter *Limiter) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := limiter.store.Take(r.Context(), "ip|"+limiter.clientIP(r), limiter.config.PorIP)
+			if err != nil {
+				logging.FromContext(r.Context()).WithError(err).Error("Failed to check rate limit")
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !limiter.respond(w, r, result) {
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), resultKey{}, result)))
+		})
+	}
+}
+
+// Middleware limita as requisições de cada cliente e informa o estado do balde mais restrito,
+// inclusive o do IPMiddleware, nos cabeçalhos RateLimit-Limit, RateLimit-Remaining e
+// RateLimit-Reset. Acima do limite, responde 429 com Retry-After. Deve vir depois da
+// autenticação, para identificar o usuário; se o store falhar, a requisição é atendida sem limite.
+func Middleware(limiter *Limiter) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := limiter.take(r)
+			if err != nil {
+				logging.FromContext(r.Context()).WithError(err).Error("Failed to check rate limit")
+				next.ServeHTTP(w, r)
+				return
+			}
+			if ip, ok := r.Context().Value(resultKey{}).(Result); ok && ip.Remaining < result.Remaining {
+				result = ip
+			}
+			if limiter.respond(w, r, result) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// resultKey guarda no contexto o resultado do balde do IP, para o Middleware.
+type resultKey struct{}
+
+// respond escreve os cabeçalhos do balde e, se a requisição não for permitida, responde 429.
+// Retorna se a requisição pode seguir.
+func (l *Limiter) respond(w http.ResponseWriter, r *http.Request, result Result) bool {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	if result.Allowed {
+		return true
+	}
+
+	logger := logging.FromContext(r.Context())
+	retryAfter := max(ceilSeconds(result.RetryAfter), 1)
+	logger.WithFields(logrus.Fields{
+		"client": l.client(r),
+		"path":   r.URL.Path,
+	}).Warn("Rate limit exceeded")
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	p := problem.New(problem.TooManyRequests, r, i18n.T(i18n.FromRequest(r), "request.rate_limited", retryAfter))
+	if err := problem.Write(w, p); err != nil {
+		logger.WithError(err).Error("Failed to encode problem response")
+	}
+	return false
+}
+
+// take consome um token do balde da rota, se ela tiver limite próprio, e do balde geral do
+// cliente, retornando o estado do mais restrito. A requisição recusada pela rota não consome do
+// balde geral.
+func (l *Limiter) take(r *http.Request) (Result, error) {
+	client := l.client(r)
+
+	var result *Result
+	if route, limit, ok := l.routeLimit(r); ok {
+		res, err := l.store.Take(r.Context(), route+"|"+client, limit)
+		if err != nil || !res.Allowed {
+			return res, err
+		}
+		result = &res
+	}
+
+	res, err := l.store.Take(r.Context(), "*|"+client, l.clientLimit(r))
+	if err != nil {
+		return res, err
+	}
+	if result == nil || !res.Allowed || res.Remaining < result.Remaining {
+		result = &res
+	}
+	return *result, nil
+}
+
+// routeLimit retorna o limite próprio da rota, identificada pelo template do mux.
+func (l *Limiter) routeLimit(r *http.Request) (string, Limit, bool) {
+	template, ok := httpx.RouteTemplate(r)
+	if !ok {
+		return "", Limit{}, false
+	}
+	if limit, ok := l.config.PorRota[r.Method+" "+template]; ok {
+		return r.Method + " " + template, limit, true
+	}
+	limit, ok := l.config.PorRota[template]
+	return template, limit, ok
+}
+
+// clientLimit retorna o limite do balde geral: o maior dos limites dos papéis do usuário, ou o padrão.
+func (l *Limiter) clientLimit(r *http.Request) Limit {
+	p, ok := auth.FromContext(r.Context())
+	if !ok {
+		return l.config.Padrao
+	}
+	classes := p.Roles
+	if len(p.Scopes) > 0 {
+		classes = []string{ClasseAPIKey}
+	}
+
+	var best *Limit
+	for _, class := range classes {
+		if limit, ok := l.config.PorPapel[class]; ok && (best == nil || limit.rate() > best.rate()) {
+			best = &limit
+		}
+	}
+	if best == nil {
+		return l.config.Padrao
+	}
+	return *best
+}
+
+// client identifica o cliente: o usuário autenticado ou, sem autenticação, o endereço IP.
+func (l *Limiter) client(r *http.Request) string {
+	if p, ok := auth.FromContext(r.Context()); ok {
+		return "usuario:" + p.Subject
+	}
+	return "ip:" + l.clientIP(r)
+}
+
+func (l *Limiter) clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); l.config.ConfiarProxy && forwarded != "" {
+		addrs := strings.Split(forwarded, ",")
+		return strings.TrimSpace(addrs[len(addrs)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
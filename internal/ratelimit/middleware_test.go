@@ -0,0 +1,197 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/auth"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/problem"
+
+	"github.com/gorilla/mux"
+)
+
+// newTestRouter monta a cadeia do main.go com uma autenticação simplificada: o usuário é o do
+// cabeçalho X-Usuario, com o papel de X-Papel, e a requisição sem usuário na rota /alunos é
+// recusada com 401.
+func newTestRouter(config Config) (*mux.Router, *clock) {
+	store, c := newTestStore()
+	limiter := NewLimiter(config, store)
+	router := mux.NewRouter()
+	router.Use(IPMiddleware(limiter))
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			subject := r.Header.Get("X-Usuario")
+			if subject == "" {
+				if r.URL.Path != "/health" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			principal := &auth.Principal{Subject: subject, Roles: []string{r.Header.Get("X-Papel")}}
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	})
+	router.Use(Middleware(limiter))
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	router.HandleFunc("/alunos", ok)
+	router.HandleFunc("/alunos/search", ok)
+	router.HandleFunc("/health", ok)
+	return router, c
+}
+
+type request struct {
+	path, usuario, papel, remoteAddr, forwardedFor string
+}
+
+func (req request) do(router http.Handler) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, req.path, nil)
+	if req.usuario != "" {
+		r.Header.Set("X-Usuario", req.usuario)
+		r.Header.Set("X-Papel", req.papel)
+	}
+	if req.remoteAddr != "" {
+		r.RemoteAddr = req.remoteAddr
+	}
+	if req.forwardedFor != "" {
+		r.Header.Set("X-Forwarded-For", req.forwardedFor)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestMiddlewareHeaders(t *testing.T) {
+	config := DefaultConfig()
+	config.Padrao = Limit{Requests: 2, Period: 2 * time.Second}
+	router, c := newTestRouter(config)
+	ana := request{path: "/alunos", usuario: "ana"}
+
+	rec := ana.do(router)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	assertHeaders(t, rec, "2", "1", "1", "")
+
+	ana.do(router)
+	rec = ana.do(router)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	assertHeaders(t, rec, "2", "0", "2", "1")
+	if ct := rec.Header().Get("Content-Type"); ct != problem.ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, problem.ContentType)
+	}
+
+	c.advance(time.Second)
+	if rec := ana.do(router); rec.Code != http.StatusOK {
+		t.Errorf("status after refill = %d, want 200", rec.Code)
+	}
+}
+
+func assertHeaders(t *testing.T, rec *httptest.ResponseRecorder, limit, remaining, reset, retryAfter string) {
+	t.Helper()
+	got := [4]string{rec.Header().Get("RateLimit-Limit"), rec.Header().Get("RateLimit-Remaining"),
+		rec.Header().Get("RateLimit-Reset"), rec.Header().Get("Retry-After")}
+	if want := [4]string{limit, remaining, reset, retryAfter}; got != want {
+		t.Errorf("RateLimit-Limit, -Remaining, -Reset, Retry-After = %q, want %q", got, want)
+	}
+}
+
+func TestIPMiddlewareBeforeAuthentication(t *testing.T) {
+	config := DefaultConfig()
+	config.PorIP = Limit{Requests: 2, Period: time.Minute}
+	router, _ := newTestRouter(config)
+
+	// Credenciais inválidas são recusadas pela autenticação, mas consomem o balde do IP
+	anonimo := request{path: "/alunos", remoteAddr: "203.0.113.7:5000"}
+	for i := 0; i < 2; i++ {
+		if rec := anonimo.do(router); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("request %d: status = %d, want 401", i, rec.Code)
+		}
+	}
+	rec := anonimo.do(router)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429 before authentication", rec.Code)
+	}
+	assertHeaders(t, rec, "2", "0", "60", "30")
+
+	// O mesmo IP fica limitado também para usuários autenticados; outro IP não
+	if rec := (request{path: "/alunos", usuario: "ana", remoteAddr: "203.0.113.7:5001"}).do(router); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("authenticated request from the limited IP: status = %d, want 429", rec.Code)
+	}
+	if rec := (request{path: "/alunos", usuario: "ana", remoteAddr: "198.51.100.1:5000"}).do(router); rec.Code != http.StatusOK {
+		t.Errorf("request from another IP: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestMiddlewareMostRestrictiveBucket(t *testing.T) {
+	config := DefaultConfig()
+	config.PorIP = Limit{Requests: 3, Period: time.Minute}
+	config.Padrao = Limit{Requests: 10, Period: time.Minute}
+	config.PorRota = map[string]Limit{"GET /alunos/search": {Requests: 5, Period: time.Minute}}
+	router, _ := newTestRouter(config)
+
+	// O balde do IP (3) é o mais restrito
+	rec := (request{path: "/alunos", usuario: "ana"}).do(router)
+	assertHeaders(t, rec, "3", "2", "20", "")
+
+	config.PorIP = Limit{Requests: 100, Period: time.Minute}
+	router, _ = newTestRouter(config)
+	// O balde da rota (5) é mais restrito que o geral (10)
+	rec = (request{path: "/alunos/search", usuario: "ana"}).do(router)
+	assertHeaders(t, rec, "5", "4", "12", "")
+	// Fora da rota limitada, vale o geral
+	rec = (request{path: "/alunos", usuario: "ana"}).do(router)
+	assertHeaders(t, rec, "10", "8", "12", "")
+}
+
+func TestMiddlewareRoleLimit(t *testing.T) {
+	config := DefaultConfig()
+	config.Padrao = Limit{Requests: 1, Period: time.Minute}
+	config.PorPapel = map[string]Limit{"admin": {Requests: 3, Period: time.Minute}}
+	router, _ := newTestRouter(config)
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if rec := (request{path: "/alunos", usuario: "root", papel: "admin"}).do(router); rec.Code != want {
+			t.Errorf("admin request %d: status = %d, want %d", i, rec.Code, want)
+		}
+	}
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		if rec := (request{path: "/alunos", usuario: "ana", papel: "professor"}).do(router); rec.Code != want {
+			t.Errorf("professor request %d: status = %d, want %d", i, rec.Code, want)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name         string
+		confiarProxy bool
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{name: "RemoteAddr", remoteAddr: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "X-Forwarded-For ignorado sem proxy confiável", remoteAddr: "10.0.0.1:5000", forwardedFor: "1.2.3.4", want: "10.0.0.1"},
+		{name: "último endereço do X-Forwarded-For", confiarProxy: true, remoteAddr: "10.0.0.1:5000", forwardedFor: "1.2.3.4, 203.0.113.7", want: "203.0.113.7"},
+		{name: "RemoteAddr sem porta", remoteAddr: "203.0.113.7", want: "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.ConfiarProxy = tt.confiarProxy
+			r := httptest.NewRequest(http.MethodGet, "/alunos", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			if got := NewLimiter(config, NewMemoryStore()).clientIP(r); got != tt.want {
+				t.Errorf("clientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock é um relógio controlado pelos testes.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestStore() (*MemoryStore, *clock) {
+	c := &clock{time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = c.now
+	return s, c
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	limit := Limit{Requests: 3, Period: 3 * time.Second} // um token por segundo
+	type step struct {
+		advance        time.Duration
+		wantAllowed    bool
+		wantRemaining  int
+		wantRetryAfter time.Duration
+		wantReset      time.Duration
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "começa cheio e esvazia",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2, wantReset: time.Second},
+				{wantAllowed: true, wantRemaining: 1, wantReset: 2 * time.Second},
+				{wantAllowed: true, wantRemaining: 0, wantReset: 3 * time.Second},
+				{wantAllowed: false, wantRemaining: 0, wantRetryAfter: time.Second, wantReset: 3 * time.Second},
+			},
+		},
+		{
+			name: "reabastece continuamente",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2, wantReset: time.Second},
+				{wantAllowed: true, wantRemaining: 1, wantReset: 2 * time.Second},
+				{wantAllowed: true, wantRemaining: 0, wantReset: 3 * time.Second},
+				{advance: 500 * time.Millisecond, wantAllowed: false, wantRetryAfter: 500 * time.Millisecond, wantReset: 2500 * time.Millisecond},
+				{advance: 500 * time.Millisecond, wantAllowed: true, wantRemaining: 0, wantReset: 3 * time.Second},
+			},
+		},
+		{
+			name: "não passa da capacidade",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2, wantReset: time.Second},
+				{advance: time.Hour, wantAllowed: true, wantRemaining: 2, wantReset: time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newTestStore()
+			for i, st := range tt.steps {
+				c.advance(st.advance)
+				got, err := s.Take(context.Background(), "cliente", limit)
+				if err != nil {
+					t.Fatalf("step %d: Take: %v", i, err)
+				}
+				want := Result{Allowed: st.wantAllowed, Limit: 3, Remaining: st.wantRemaining, RetryAfter: st.wantRetryAfter, Reset: st.wantReset}
+				if got != want {
+					t.Errorf("step %d: result = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreBucketsAreIndependent(t *testing.T) {
+	s, _ := newTestStore()
+	limit := Limit{Requests: 1, Period: time.Minute}
+	if res, _ := s.Take(context.Background(), "a", limit); !res.Allowed {
+		t.Fatal("first request of a denied")
+	}
+	if res, _ := s.Take(context.Background(), "a", limit); res.Allowed {
+		t.Error("second request of a allowed")
+	}
+	if res, _ := s.Take(context.Background(), "b", limit); !res.Allowed {
+		t.Error("first request of b denied")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	s, c := newTestStore()
+	limit := Limit{Requests: 10, Period: 10 * time.Second}
+	s.Take(context.Background(), "parado", limit)
+	c.advance(sweepInterval)
+	s.Take(context.Background(), "ativo", limit)
+	if _, ok := s.buckets["parado"]; ok {
+		t.Error("full bucket was not swept")
+	}
+	if _, ok := s.buckets["ativo"]; !ok {
+		t.Error("active bucket was swept")
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// alunoAsOfFrom substitui alunoFrom nas consultas "como estava em": as versões da tabela
+// alunos_historico com os mesmos aliases, sendo p.nome o nome do professor na época.
+// As consultas devem filtrar as versões válidas no instante com alunoValidoEm.
+const (
+	alunoAsOfFrom  = " FROM alunos_historico a CROSS JOIN LATERAL (SELECT a.nome_professor AS nome) p"
+	alunoValidoEm  = "a.valid_from <= ? AND (a.valid_to IS NULL OR a.valid_to > ?)"
+	historicoFecha = "UPDATE alunos_historico SET valid_to = now() WHERE id = $1 AND valid_to IS NULL"
+	historicoGrava = `
+		INSERT INTO alunos_historico (id, version, nome, idade, professor_id, nome_professor, numero_sala,
+		                              media, situacao, created_at, updated_at, deleted_at, valid_from)
+		SELECT a.id, a.version, a.nome, a.idade, a.professor_id, COALESCE(p.nome, ''), a.numero_sala,
+		       a.media, a.situacao, a.created_at, a.updated_at, a.deleted_at, now()
+		FROM alunos a
+		LEFT JOIN professores p ON p.id = a.professor_id
+		WHERE a.id = $1`
+)
+
+// recordHistorico encerra a versão vigente do aluno e grava o estado atual, já alterado na
+// transação, como a nova versão.
+func recordHistorico(ctx context.Context, tx *sql.Tx, id int) error {
+	if _, err := execContext(ctx, tx, historicoFecha, id); err != nil {
+		return err
+	}
+	_, err := execContext(ctx, tx, historicoGrava, id)
+	return err
+}
+
+// recordChange registra uma alteração do aluno na auditoria e no histórico de versões.
+func recordChange(ctx context.Context, tx *sql.Tx, id int, operacao string, antes, depois []byte) error {
+	if err := recordAuditoria(ctx, tx, id, operacao, antes, depois); err != nil {
+		return err
+	}
+	return recordHistorico(ctx, tx, id)
+}
+
+// touchAlunos registra as alterações feitas nos alunos por outro cadastro na mesma transação
+// (a sala renumerada, o professor renomeado ou removido): os alunos cujo estado mudou desde
+// antes, obtido com snapshotAlunos, ganham nova versão (e portanto novo ETag) e são registrados
+// na auditoria e no histórico.
+func touchAlunos(ctx context.Context, tx *sql.Tx, antes map[int][]byte) error {
+	if len(antes) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(antes))
+	for id := range antes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	depois, err := snapshotAlunos(ctx, tx, "id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if bytes.Equal(antes[id], depois[id]) {
+			continue
+		}
+		if _, err := execContext(ctx, tx, "UPDATE alunos SET "+touchAluno+" WHERE id = $1", id); err != nil {
+			return err
+		}
+		if err := recordChange(ctx, tx, id, models.OperacaoAlteracao, antes[id], depois[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
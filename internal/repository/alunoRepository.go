@@ -1,17 +1,60 @@
 package repository
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/actor"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/lib/pq"
 )
 
+// AlunoRepository persiste os alunos. Criações, alterações e remoções são registradas na
+// auditoria em nome do ator do contexto (actor.FromContext).
 type AlunoRepository interface {
-	GetAll() ([]models.Aluno, error)
-	GetByID(id int) (*models.Aluno, error)
-	Create(aluno *models.Aluno) error
-	Update(aluno *models.Aluno) error
-	Delete(id int) error
+	List(ctx context.Context, query models.AlunoQuery) (*models.AlunoPage, error)
+	Search(ctx context.Context, term string, limit int, ids []int) ([]models.AlunoSearchResult, error)
+	GetByID(ctx context.Context, id int) (*models.Aluno, error)
+	GetByIDIncludingDeleted(ctx context.Context, id int) (*models.Aluno, error)
+	GetByIDAsOf(ctx context.Context, id int, asOf time.Time) (*models.Aluno, error)
+	Create(ctx context.Context, aluno *models.Aluno) error
+	Update(ctx context.Context, aluno *models.Aluno) error
+	UpdatePartial(ctx context.Context, id, version int, patch models.AlunoPatch) error
+	UpdateSituacao(ctx context.Context, aluno *models.Aluno) error
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, aluno *models.Aluno) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+	Changes(ctx context.Context, since string, limit int, ids []int) (*models.AlunoChanges, error)
+}
+
+// alunoFrom junta o professor ao aluno; as consultas usam os aliases a (alunos) e p (professores).
+// Alunos removidos (deleted_at preenchido) só aparecem em Changes, GetByIDIncludingDeleted e
+// List com IncludeDeleted.
+const (
+	alunoColumns = "a.id, a.nome, a.idade, a.professor_id, COALESCE(p.nome, ''), a.numero_sala, a.media, a.situacao, a.version, a.created_at, a.updated_at, a.deleted_at"
+	alunoFrom    = " FROM alunos a LEFT JOIN professores p ON p.id = a.professor_id"
+)
+
+// sortableColumns mapeia os campos aceitos em ?sort= para as expressões SQL correspondentes.
+var sortableColumns = map[string]string{
+	"id":             "a.id",
+	"nome":           "a.nome",
+	"idade":          "a.idade",
+	"professor_id":   "COALESCE(a.professor_id, 0)",
+	"nome_professor": "COALESCE(p.nome, '')",
+	"numero_sala":    "a.numero_sala",
+	"media":          "COALESCE(a.media, -1)",
+	"situacao":       "COALESCE(a.situacao, '')",
 }
 
 type alunoRepository struct {
@@ -22,47 +65,755 @@ func NewAlunoRepository(db *sql.DB) AlunoRepository {
 	return &alunoRepository{db}
 }
 
-func (r *alunoRepository) GetAll() ([]models.Aluno, error) {
-	rows, err := r.db.Query("SELECT * FROM alunos")
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// alunoFields retorna os destinos de Scan na mesma ordem de alunoColumns.
+func alunoFields(aluno *models.Aluno) []interface{} {
+	return []interface{}{&aluno.ID, &aluno.Nome, &aluno.Idade, &aluno.ProfessorID, &aluno.NomeProfessor, &aluno.NumeroSala, &aluno.Media, &aluno.Situacao, &aluno.Version, &aluno.CreatedAt, &aluno.UpdatedAt, &aluno.DeletedAt}
+}
+
+func scanAluno(row rowScanner) (*models.Aluno, error) {
+	var aluno models.Aluno
+	if err := row.Scan(alunoFields(&aluno)...); err != nil {
+		return nil, err
+	}
+	return &aluno, nil
+}
+
+// alunoCursor é o conteúdo (opaco para o cliente) do cursor de paginação por keyset:
+// o campo de ordenação, o valor desse campo e o id do último aluno da página.
+type alunoCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func encodeCursor(c alunoCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*alunoCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, models.InvalidQuery("query.malformed_cursor")
+	}
+	var c alunoCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, models.InvalidQuery("query.malformed_cursor")
+	}
+	return &c, nil
+}
+
+func sortValue(aluno *models.Aluno, field string) string {
+	switch field {
+	case "nome":
+		return aluno.Nome
+	case "idade":
+		return strconv.Itoa(aluno.Idade)
+	case "professor_id":
+		if aluno.ProfessorID == nil {
+			return "0"
+		}
+		return strconv.Itoa(*aluno.ProfessorID)
+	case "nome_professor":
+		return aluno.NomeProfessor
+	case "numero_sala":
+		return strconv.Itoa(aluno.NumeroSala)
+	case "media":
+		if aluno.Media == nil {
+			return "-1"
+		}
+		return strconv.FormatFloat(*aluno.Media, 'f', -1, 64)
+	case "situacao":
+		if aluno.Situacao == nil {
+			return ""
+		}
+		return *aluno.Situacao
+	default:
+		return strconv.Itoa(aluno.ID)
+	}
+}
+
+// whereBuilder acumula condições e argumentos posicionais ($1, $2, ...) de uma consulta.
+type whereBuilder struct {
+	conds []string
+	args  []interface{}
+}
+
+func (b *whereBuilder) add(cond string, values ...interface{}) {
+	for _, v := range values {
+		b.args = append(b.args, v)
+		cond = strings.Replace(cond, "?", "$"+strconv.Itoa(len(b.args)), 1)
+	}
+	b.conds = append(b.conds, cond)
+}
+
+func (b *whereBuilder) String() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conds, " AND ")
+}
+
+func alunoFilters(query models.AlunoQuery) *whereBuilder {
+	where := &whereBuilder{}
+	if !query.IncludeDeleted {
+		where.add("a.deleted_at IS NULL")
+	}
+	if query.IDs != nil {
+		where.add("a.id = ANY(?)", pq.Array(query.IDs))
+	}
+	if query.ProfessorID != nil {
+		where.add("a.professor_id = ?", *query.ProfessorID)
+	}
+	if query.NomeProfessor != "" {
+		where.add("normalizar_nome_professor(p.nome) = normalizar_nome_professor(?)", query.NomeProfessor)
+	}
+	if query.NumeroSala != nil {
+		where.add("a.numero_sala = ?", *query.NumeroSala)
+	}
+	if query.IdadeMin != nil {
+		where.add("a.idade >= ?", *query.IdadeMin)
+	}
+	if query.IdadeMax != nil {
+		where.add("a.idade <= ?", *query.IdadeMax)
+	}
+	if query.Situacao != "" {
+		where.add("a.situacao = ?", query.Situacao)
+	}
+	if query.MediaMin != nil {
+		where.add("a.media >= ?", *query.MediaMin)
+	}
+	if query.MediaMax != nil {
+		where.add("a.media <= ?", *query.MediaMax)
+	}
+	return where
+}
+
+func (r *alunoRepository) List(ctx context.Context, query models.AlunoQuery) (*models.AlunoPage, error) {
+	ctx, end := trace(ctx, "aluno", "List")
+	defer end()
+
+	sortField := query.Sort
+	if sortField == "" {
+		sortField = "id"
+	}
+	column, ok := sortableColumns[sortField]
+	if !ok {
+		return nil, models.InvalidQuery("query.unknown_sort", sortField)
+	}
+
+	from, where := alunoFrom, alunoFilters(query)
+	if query.AsOf != nil {
+		from = alunoAsOfFrom
+		where.add(alunoValidoEm, *query.AsOf, *query.AsOf)
+	}
+
+	var total int
+	if err := queryRowContext(ctx, r.db, "SELECT COUNT(*)"+from+where.String(), where.args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	direction, comparison := "ASC", ">"
+	if query.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Sort != sortField || cursor.Desc != query.Desc {
+			return nil, models.InvalidQuery("query.cursor_sort_mismatch")
+		}
+		if sortField == "id" {
+			where.add("a.id "+comparison+" ?", cursor.ID)
+		} else {
+			where.add(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND a.id %[2]s ?))", column, comparison), cursor.Value, cursor.Value, cursor.ID)
+		}
+	}
+
+	sqlQuery := fmt.Sprintf("SELECT %s%s%s ORDER BY %s %s", alunoColumns, from, where.String(), column, direction)
+	if sortField != "id" {
+		sqlQuery += ", a.id " + direction
+	}
+	// Uma linha além do limite indica se há uma próxima página
+	args := append(where.args, query.Limit+1)
+	sqlQuery += " LIMIT $" + strconv.Itoa(len(args))
+	if query.Cursor == "" && query.Offset > 0 {
+		args = append(args, query.Offset)
+		sqlQuery += " OFFSET $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := queryContext(ctx, r.db, sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var alunos []models.Aluno
+	alunos := []models.Aluno{}
 	for rows.Next() {
-		var aluno models.Aluno
-		if err := rows.Scan(&aluno.ID, &aluno.Nome, &aluno.Idade, &aluno.NotaPrimeiroSemestre, &aluno.NotaSegundoSemestre, &aluno.NomeProfessor, &aluno.NumeroSala); err != nil {
+		aluno, err := scanAluno(rows)
+		if err != nil {
 			return nil, err
 		}
-		alunos = append(alunos, aluno)
+		alunos = append(alunos, *aluno)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return alunos, nil
+	alunos, nextCursor := paginate(alunos, query.Limit, sortField, query.Desc)
+	return &models.AlunoPage{
+		Data: alunos,
+		Meta: models.PageMeta{Total: total, Limit: query.Limit, Offset: query.Offset, NextCursor: nextCursor},
+	}, nil
 }
 
-func (r *alunoRepository) GetByID(id int) (*models.Aluno, error) {
-	var aluno models.Aluno
-	err := r.db.QueryRow("SELECT * FROM alunos WHERE id = $1", id).Scan(&aluno.ID, &aluno.Nome, &aluno.Idade, &aluno.NotaPrimeiroSemestre, &aluno.NotaSegundoSemestre, &aluno.NomeProfessor, &aluno.NumeroSala)
+// paginate recebe até limit+1 alunos e retorna a página (os primeiros limit) e o cursor da
+// próxima página, vazio quando não há a linha excedente, ou seja, quando esta é a última.
+func paginate(alunos []models.Aluno, limit int, sortField string, desc bool) ([]models.Aluno, string) {
+	if len(alunos) <= limit {
+		return alunos, ""
+	}
+	alunos = alunos[:limit]
+	last := &alunos[len(alunos)-1]
+	return alunos, encodeCursor(alunoCursor{Sort: sortField, Desc: desc, Value: sortValue(last, sortField), ID: last.ID})
+}
+
+// searchTSQuery transforma o termo digitado em uma tsquery de prefixos ("joão si" -> "joão:* & si:*"),
+// descartando a pontuação para que a entrada do usuário não quebre a sintaxe do to_tsquery.
+func searchTSQuery(term string) string {
+	words := strings.FieldsFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// Delimitadores das correspondências no ts_headline. São caracteres de controle, que não
+// aparecem nos nomes, para que o texto seja escapado antes de receber as tags <mark>.
+const (
+	highlightStart  = "\x02"
+	highlightStop   = "\x03"
+	headlineOptions = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", HighlightAll=true`
+)
+
+var highlightTags = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// highlightHTML converte o resultado do ts_headline em HTML: escapa o texto (um nome com
+// "<script>" chega ao cliente como texto) e troca os delimitadores por <mark></mark>. Retorna
+// false se não houve correspondência no campo.
+func highlightHTML(headline string) (string, bool) {
+	if !strings.Contains(headline, highlightStart) {
+		return "", false
+	}
+	return highlightTags.Replace(html.EscapeString(headline)), true
+}
+
+// Search busca os alunos pelo nome do aluno ou do professor. ids, quando não é nil, restringe a
+// busca aos alunos informados.
+func (r *alunoRepository) Search(ctx context.Context, term string, limit int, ids []int) ([]models.AlunoSearchResult, error) {
+	ctx, end := trace(ctx, "aluno", "Search")
+	defer end()
+
+	tsquery := searchTSQuery(term)
+	if tsquery == "" {
+		return nil, models.InvalidQuery("query.empty_search")
+	}
+
+	args := []interface{}{tsquery, term, limit, headlineOptions}
+	scope := ""
+	if ids != nil {
+		args = append(args, pq.Array(ids))
+		scope = " AND a.id = ANY($5)"
+	}
+
+	rows, err := queryContext(ctx, r.db, `
+		SELECT `+alunoColumns+`,
+			ts_rank(a.busca, to_tsquery('busca_alunos', $1))
+				+ 0.5 * COALESCE(ts_rank(p.busca, to_tsquery('busca_alunos', $1)), 0)
+				+ similarity(immutable_unaccent(lower(a.nome)), immutable_unaccent(lower($2))) AS relevancia,
+			ts_headline('busca_alunos', a.nome, to_tsquery('busca_alunos', $1), $4),
+			ts_headline('busca_alunos', COALESCE(p.nome, ''), to_tsquery('busca_alunos', $1), $4)`+
+		alunoFrom+`
+		WHERE a.deleted_at IS NULL AND (a.busca @@ to_tsquery('busca_alunos', $1)
+			OR p.busca @@ to_tsquery('busca_alunos', $1)
+			OR immutable_unaccent(lower(a.nome)) % immutable_unaccent(lower($2)))`+scope+`
+		ORDER BY relevancia DESC, a.id
+		LIMIT $3`, args...)
 	if err != nil {
 		return nil, err
 	}
-	return &aluno, nil
+	defer rows.Close()
+
+	results := []models.AlunoSearchResult{}
+	for rows.Next() {
+		var res models.AlunoSearchResult
+		var nome, nomeProfessor string
+		if err := rows.Scan(append(alunoFields(&res.Aluno), &res.Relevancia, &nome, &nomeProfessor)...); err != nil {
+			return nil, err
+		}
+		res.Destaques = map[string]string{}
+		if destaque, ok := highlightHTML(nome); ok {
+			res.Destaques["nome"] = destaque
+		}
+		if destaque, ok := highlightHTML(nomeProfessor); ok {
+			res.Destaques["nome_professor"] = destaque
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+func (r *alunoRepository) GetByID(ctx context.Context, id int) (*models.Aluno, error) {
+	ctx, end := trace(ctx, "aluno", "GetByID")
+	defer end()
+
+	return r.getByID(ctx, id, " AND a.deleted_at IS NULL")
+}
+
+func (r *alunoRepository) GetByIDIncludingDeleted(ctx context.Context, id int) (*models.Aluno, error) {
+	ctx, end := trace(ctx, "aluno", "GetByIDIncludingDeleted")
+	defer end()
+
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDAsOf retorna o aluno como estava no instante asOf, inclusive se já estivesse removido.
+func (r *alunoRepository) GetByIDAsOf(ctx context.Context, id int, asOf time.Time) (*models.Aluno, error) {
+	ctx, end := trace(ctx, "aluno", "GetByIDAsOf")
+	defer end()
+
+	where := &whereBuilder{}
+	where.add("a.id = ?", id)
+	where.add(alunoValidoEm, asOf, asOf)
+	aluno, err := scanAluno(queryRowContext(ctx, r.db, "SELECT "+alunoColumns+alunoAsOfFrom+where.String(), where.args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAlunoNotFound
+	}
+	return aluno, err
+}
+
+func (r *alunoRepository) getByID(ctx context.Context, id int, cond string) (*models.Aluno, error) {
+	aluno, err := scanAluno(queryRowContext(ctx, r.db, "SELECT "+alunoColumns+alunoFrom+" WHERE a.id = $1"+cond, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAlunoNotFound
+	}
+	return aluno, err
+}
+
+// reservarVaga garante, na transação que grava o aluno, que a sala numero tem vaga para ele. A
+// linha da sala fica bloqueada até o fim da transação: matrículas concorrentes na mesma sala são
+// contadas uma depois da outra e não ultrapassam a capacidade. O aluno que já está matriculado
+// na sala (alunoID, 0 para um aluno novo) já ocupa uma das vagas.
+func reservarVaga(ctx context.Context, tx *sql.Tx, numero, alunoID int) error {
+	var capacidade int
+	err := queryRowContext(ctx, tx, "SELECT capacidade FROM salas WHERE numero = $1 FOR UPDATE", numero).Scan(&capacidade)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrSalaNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var ocupacao int
+	var matriculado bool
+	err = queryRowContext(ctx, tx, "SELECT COUNT(*), COALESCE(bool_or(id = $2), false) FROM alunos WHERE numero_sala = $1 AND deleted_at IS NULL",
+		numero, alunoID).Scan(&ocupacao, &matriculado)
+	if err != nil {
+		return err
+	}
+	if !matriculado && ocupacao >= capacidade {
+		return models.ErrSalaFull
+	}
+	return nil
+}
+
+// As alterações do aluno são gravadas em uma transação junto com a entrada da auditoria.
+// O aluno é bloqueado (snapshotAluno) antes de ser alterado: se ele não existir, a alteração
+// falha com ErrAlunoNotFound; se existir, Update, UpdatePartial e Delete ainda exigem que ele
+// esteja na versão esperada (ou que a versão esperada seja models.AnyVersion).
+
+func (r *alunoRepository) Create(ctx context.Context, aluno *models.Aluno) error {
+	ctx, end := trace(ctx, "aluno", "Create")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := reservarVaga(ctx, tx, aluno.NumeroSala, 0); err != nil {
+			return err
+		}
+		var depois []byte
+		err := queryRowContext(ctx, tx, "INSERT INTO alunos (nome, idade, professor_id, numero_sala) VALUES ($1, $2, $3, $4) RETURNING id, version, created_at, updated_at, "+alunoSnapshot,
+			aluno.Nome, aluno.Idade, aluno.ProfessorID, aluno.NumeroSala).Scan(&aluno.ID, &aluno.Version, &aluno.CreatedAt, &aluno.UpdatedAt, &depois)
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, aluno.ID, models.OperacaoCriacao, nil, depois)
+	})
+}
+
+// touchAluno registra uma alteração do aluno: nova versão e nova data de alteração, que
+// posiciona a alteração na sincronização incremental.
+const touchAluno = "version = version + 1, updated_at = now()"
+
+// Update grava o aluno se ele ainda estiver em aluno.Version e atualiza aluno.Version para a nova versão.
+func (r *alunoRepository) Update(ctx context.Context, aluno *models.Aluno) error {
+	ctx, end := trace(ctx, "aluno", "Update")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes, err := snapshotAluno(ctx, tx, aluno.ID, alunoAtivo)
+		if err != nil {
+			return err
+		}
+		if err := reservarVaga(ctx, tx, aluno.NumeroSala, aluno.ID); err != nil {
+			return err
+		}
+		var depois []byte
+		err = queryRowContext(ctx, tx, `
+			UPDATE alunos SET nome = $1, idade = $2, professor_id = $3, numero_sala = $4, `+touchAluno+`
+			WHERE id = $5 AND ($6 = 0 OR version = $6)
+			RETURNING version, created_at, updated_at, `+alunoSnapshot,
+			aluno.Nome, aluno.Idade, aluno.ProfessorID, aluno.NumeroSala, aluno.ID, aluno.Version).Scan(&aluno.Version, &aluno.CreatedAt, &aluno.UpdatedAt, &depois)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrAlunoVersionMismatch
+		}
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, aluno.ID, models.OperacaoAlteracao, antes, depois)
+	})
+}
+
+// UpdatePartial altera apenas as colunas presentes no patch. O professor já deve ter sido
+// resolvido para ProfessorID (ou ClearProfessor) pelo serviço.
+func (r *alunoRepository) UpdatePartial(ctx context.Context, id, version int, patch models.AlunoPatch) error {
+	ctx, end := trace(ctx, "aluno", "UpdatePartial")
+	defer end()
+
+	var sets []string
+	var args []interface{}
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		sets = append(sets, column+" = $"+strconv.Itoa(len(args)))
+	}
+
+	if patch.Nome != nil {
+		set("nome", *patch.Nome)
+	}
+	if patch.Idade != nil {
+		set("idade", *patch.Idade)
+	}
+	if patch.NumeroSala != nil {
+		set("numero_sala", *patch.NumeroSala)
+	}
+	if patch.ProfessorID != nil {
+		set("professor_id", *patch.ProfessorID)
+	} else if patch.ClearProfessor {
+		set("professor_id", nil)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	args = append(args, id, version)
+	idParam, versionParam := "$"+strconv.Itoa(len(args)-1), "$"+strconv.Itoa(len(args))
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes, err := snapshotAluno(ctx, tx, id, alunoAtivo)
+		if err != nil {
+			return err
+		}
+		if patch.NumeroSala != nil {
+			if err := reservarVaga(ctx, tx, *patch.NumeroSala, id); err != nil {
+				return err
+			}
+		}
+		var depois []byte
+		err = queryRowContext(ctx, tx, "UPDATE alunos SET "+strings.Join(sets, ", ")+", "+touchAluno+
+			" WHERE id = "+idParam+" AND ("+versionParam+" = 0 OR version = "+versionParam+") RETURNING "+alunoSnapshot, args...).Scan(&depois)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrAlunoVersionMismatch
+		}
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, id, models.OperacaoAlteracao, antes, depois)
+	})
+}
+
+// UpdateSituacao grava a média e a situação do aluno, se ele ainda estiver em aluno.Version (ou
+// com models.AnyVersion), e atualiza aluno com a nova versão: a mudança altera a representação
+// do aluno, então também invalida o ETag anterior.
+func (r *alunoRepository) UpdateSituacao(ctx context.Context, aluno *models.Aluno) error {
+	ctx, end := trace(ctx, "aluno", "UpdateSituacao")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes, err := snapshotAluno(ctx, tx, aluno.ID, alunoAtivo)
+		if err != nil {
+			return err
+		}
+		var depois []byte
+		err = queryRowContext(ctx, tx, "UPDATE alunos SET media = $1, situacao = $2, "+touchAluno+
+			" WHERE id = $3 AND ($4 = 0 OR version = $4) RETURNING version, updated_at, "+alunoSnapshot,
+			aluno.Media, aluno.Situacao, aluno.ID, aluno.Version).Scan(&aluno.Version, &aluno.UpdatedAt, &depois)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrAlunoVersionMismatch
+		}
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, aluno.ID, models.OperacaoSituacao, antes, depois)
+	})
+}
+
+// Delete marca o aluno como removido. A linha é mantida para que a remoção chegue aos clientes
+// pela sincronização incremental.
+func (r *alunoRepository) Delete(ctx context.Context, id, version int) error {
+	ctx, end := trace(ctx, "aluno", "Delete")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes, err := snapshotAluno(ctx, tx, id, alunoAtivo)
+		if err != nil {
+			return err
+		}
+		var depois []byte
+		err = queryRowContext(ctx, tx, "UPDATE alunos SET deleted_at = now(), "+touchAluno+
+			" WHERE id = $1 AND ($2 = 0 OR version = $2) RETURNING "+alunoSnapshot, id, version).Scan(&depois)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrAlunoVersionMismatch
+		}
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, tx, id, models.OperacaoRemocao, antes, depois)
+	})
+}
+
+// Restore desfaz a remoção do aluno, se ainda houver vaga na sala dele, e atualiza aluno com a
+// nova versão.
+func (r *alunoRepository) Restore(ctx context.Context, aluno *models.Aluno) error {
+	ctx, end := trace(ctx, "aluno", "Restore")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes, err := snapshotAluno(ctx, tx, aluno.ID, alunoRemovido)
+		if err != nil {
+			return err
+		}
+		if err := reservarVaga(ctx, tx, aluno.NumeroSala, aluno.ID); err != nil {
+			return err
+		}
+		var depois []byte
+		err = queryRowContext(ctx, tx, "UPDATE alunos SET deleted_at = NULL, "+touchAluno+" WHERE id = $1 RETURNING version, updated_at, "+alunoSnapshot,
+			aluno.ID).Scan(&aluno.Version, &aluno.UpdatedAt, &depois)
+		if err != nil {
+			return err
+		}
+		aluno.DeletedAt = nil
+		return recordChange(ctx, tx, aluno.ID, models.OperacaoRestauracao, antes, depois)
+	})
+}
+
+// Purge exclui definitivamente, com as suas notas, os alunos removidos antes de deletedBefore
+// e retorna quantos foram excluídos. Os dados pessoais não sobrevivem ao expurgo: as versões do
+// aluno em alunos_historico são excluídas, as entradas da auditoria perdem os campos alterados
+// (ficam o ator, a operação e a data) e o expurgo é registrado apenas com o id do aluno e o ator.
+func (r *alunoRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, end := trace(ctx, "aluno", "Purge")
+	defer end()
+
+	var purged int64
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var ids []int64
+		err := queryRowContext(ctx, tx, `
+			WITH expurgados AS (DELETE FROM alunos WHERE deleted_at < $1 RETURNING id)
+			SELECT COALESCE(array_agg(id), '{}') FROM expurgados`, deletedBefore).Scan(pq.Array(&ids))
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		purged = int64(len(ids))
+
+		if _, err := execContext(ctx, tx, "DELETE FROM alunos_historico WHERE id = ANY($1)", pq.Array(ids)); err != nil {
+			return err
+		}
+		if _, err := execContext(ctx, tx, "UPDATE auditoria SET diff = '{}' WHERE aluno_id = ANY($1)", pq.Array(ids)); err != nil {
+			return err
+		}
+		_, err = execContext(ctx, tx, `
+			INSERT INTO auditoria (aluno_id, ator, operacao, diff)
+			SELECT id, $2, $3, '{}' FROM unnest($1::int[]) AS id`,
+			pq.Array(ids), actor.FromContext(ctx), models.OperacaoExpurgo)
+		return err
+	})
+	return purged, err
+}
+
+// syncToken é o conteúdo (opaco para o cliente) do token de sincronização: a posição da última
+// alteração entregue em alunos_alteracoes, na ordem (xid, seq).
+type syncToken struct {
+	XID uint64 `json:"x"`
+	Seq int64  `json:"s"`
+}
+
+func encodeSyncToken(t syncToken) string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSyncToken rejeita os tokens em outro formato, inclusive os das versões anteriores da
+// sincronização: o cliente precisa recomeçar pela sincronização inicial.
+func decodeSyncToken(s string) (*syncToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, models.InvalidQuery("query.malformed_sync_token")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var t syncToken
+	if err := decoder.Decode(&t); err != nil {
+		return nil, models.InvalidQuery("query.malformed_sync_token")
+	}
+	return &t, nil
 }
 
-func (r *alunoRepository) Create(aluno *models.Aluno) error {
-	err := r.db.QueryRow("INSERT INTO alunos (nome, idade, nota_primeiro_semestre, nota_segundo_semestre, nome_professor, numero_sala) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
-		aluno.Nome, aluno.Idade, aluno.NotaPrimeiroSemestre, aluno.NotaSegundoSemestre, aluno.NomeProfessor, aluno.NumeroSala).Scan(&aluno.ID)
-	return err
+// alteracao é uma entrada de alunos_alteracoes.
+type alteracao struct {
+	token    syncToken
+	alunoID  int
+	operacao string
+	data     time.Time
 }
 
-func (r *alunoRepository) Update(aluno *models.Aluno) error {
-	_, err := r.db.Exec("UPDATE alunos SET nome = $1, idade = $2, nota_primeiro_semestre = $3, nota_segundo_semestre = $4, nome_professor = $5, numero_sala = $6 WHERE id = $7",
-		aluno.Nome, aluno.Idade, aluno.NotaPrimeiroSemestre, aluno.NotaSegundoSemestre, aluno.NomeProfessor, aluno.NumeroSala, aluno.ID)
-	return err
+// Changes retorna até limit alterações posteriores ao token since, separadas em criados,
+// alterados e removidos, considerando apenas os alunos em ids (nil para todos). Sem token, é a
+// sincronização inicial: todos os alunos existentes, como criados.
+//
+// As alterações vêm de alunos_alteracoes (ver a migração 000015), que só entrega as transações
+// já encerradas na ordem dos IDs de transação, e trazem o estado atual de cada aluno alterado.
+// Os alunos já expurgados são informados como removidos, com a data do expurgo.
+func (r *alunoRepository) Changes(ctx context.Context, since string, limit int, ids []int) (*models.AlunoChanges, error) {
+	ctx, end := trace(ctx, "aluno", "Changes")
+	defer end()
+
+	var from syncToken
+	if since != "" {
+		t, err := decodeSyncToken(since)
+		if err != nil {
+			return nil, err
+		}
+		from = *t
+	}
+
+	where := &whereBuilder{}
+	where.add("(l.xid, l.seq) > (?::text::xid8, ?::bigint)", strconv.FormatUint(from.XID, 10), from.Seq)
+	// Transações com xid a partir do horizonte podem estar em andamento: as suas alterações, e as
+	// de todas as posteriores, ficam para a próxima sincronização.
+	where.add("l.xid < pg_snapshot_xmin(pg_current_snapshot())")
+	if ids != nil {
+		where.add("l.aluno_id = ANY(?)", pq.Array(ids))
+	}
+	args := append(where.args, limit+1)
+	rows, err := queryContext(ctx, r.db, "SELECT l.xid::text, l.seq, l.aluno_id, l.operacao, l.data FROM alunos_alteracoes l"+
+		where.String()+" ORDER BY l.xid, l.seq LIMIT $"+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := &models.AlunoChanges{Created: []models.Aluno{}, Updated: []models.Aluno{}, Deleted: []models.AlunoRemoval{}, NextToken: encodeSyncToken(from)}
+	var page []alteracao
+	for rows.Next() {
+		if len(page) == limit {
+			changes.HasMore = true
+			break
+		}
+		var a alteracao
+		var xid string
+		if err := rows.Scan(&xid, &a.token.Seq, &a.alunoID, &a.operacao, &a.data); err != nil {
+			return nil, err
+		}
+		if a.token.XID, err = strconv.ParseUint(xid, 10, 64); err != nil {
+			return nil, err
+		}
+		page = append(page, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(page) == 0 {
+		return changes, nil
+	}
+	changes.NextToken = encodeSyncToken(page[len(page)-1].token)
+
+	// Cada aluno aparece uma vez, na posição da sua última alteração na página, e conta como
+	// criado se a página inclui a sua criação.
+	last := map[int]alteracao{}
+	created := map[int]bool{}
+	for _, a := range page {
+		last[a.alunoID] = a
+		if a.operacao == "INSERT" {
+			created[a.alunoID] = true
+		}
+	}
+	alunoIDs := make([]int, 0, len(last))
+	for id := range last {
+		alunoIDs = append(alunoIDs, id)
+	}
+	current, err := r.alunosByID(ctx, alunoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range page {
+		if last[a.alunoID].token != a.token {
+			continue
+		}
+		aluno, exists := current[a.alunoID]
+		switch {
+		case !exists || aluno.DeletedAt != nil:
+			// A sincronização inicial não tem o que remover.
+			if since == "" {
+				continue
+			}
+			removal := models.AlunoRemoval{ID: a.alunoID, DeletedAt: a.data}
+			if exists {
+				removal.DeletedAt = *aluno.DeletedAt
+			}
+			changes.Deleted = append(changes.Deleted, removal)
+		case since == "" || created[a.alunoID]:
+			changes.Created = append(changes.Created, *aluno)
+		default:
+			changes.Updated = append(changes.Updated, *aluno)
+		}
+	}
+	return changes, nil
 }
 
-func (r *alunoRepository) Delete(id int) error {
-	_, err := r.db.Exec("DELETE FROM alunos WHERE id = $1", id)
-	return err
+// alunosByID retorna os alunos com os IDs informados, inclusive os removidos.
+func (r *alunoRepository) alunosByID(ctx context.Context, ids []int) (map[int]*models.Aluno, error) {
+	rows, err := queryContext(ctx, r.db, "SELECT "+alunoColumns+alunoFrom+" WHERE a.id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alunos := map[int]*models.Aluno{}
+	for rows.Next() {
+		aluno, err := scanAluno(rows)
+		if err != nil {
+			return nil, err
+		}
+		alunos[aluno.ID] = aluno
+	}
+	return alunos, rows.Err()
 }
@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []alunoCursor{
+		{Sort: "id", ID: 42},
+		{Sort: "nome", Desc: true, Value: "João da Conceição", ID: 7},
+		{Sort: "media", Value: "-1", ID: 1},
+	}
+	for _, want := range tests {
+		got, err := decodeCursor(encodeCursor(want))
+		if err != nil {
+			t.Fatalf("decodeCursor(encodeCursor(%+v)): %v", want, err)
+		}
+		if *got != want {
+			t.Errorf("cursor = %+v, want %+v", *got, want)
+		}
+	}
+}
+
+func TestDecodeCursorMalformed(t *testing.T) {
+	for _, cursor := range []string{"não é base64!", "bm90IGpzb24", "e30=garbage"} {
+		if _, err := decodeCursor(cursor); !errors.Is(err, models.ErrInvalidQuery) {
+			t.Errorf("decodeCursor(%q) error = %v, want ErrInvalidQuery", cursor, err)
+		}
+	}
+}
+
+func alunos(ids ...int) []models.Aluno {
+	list := make([]models.Aluno, len(ids))
+	for i, id := range ids {
+		list[i] = models.Aluno{ID: id, Nome: "Aluno " + string(rune('A'+i))}
+	}
+	return list
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name       string
+		rows       []models.Aluno
+		limit      int
+		sort       string
+		desc       bool
+		wantIDs    []int
+		wantCursor *alunoCursor
+	}{
+		{name: "vazia", rows: alunos(), limit: 3, sort: "id"},
+		{name: "menor que o limite", rows: alunos(1, 2), limit: 3, sort: "id", wantIDs: []int{1, 2}},
+		{name: "exatamente o limite", rows: alunos(1, 2, 3), limit: 3, sort: "id", wantIDs: []int{1, 2, 3}},
+		{
+			name: "linha excedente", rows: alunos(1, 2, 3, 4), limit: 3, sort: "id",
+			wantIDs: []int{1, 2, 3}, wantCursor: &alunoCursor{Sort: "id", Value: "3", ID: 3},
+		},
+		{
+			name: "ordenado por nome, decrescente", rows: alunos(9, 8), limit: 1, sort: "nome", desc: true,
+			wantIDs: []int{9}, wantCursor: &alunoCursor{Sort: "nome", Desc: true, Value: "Aluno A", ID: 9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, cursor := paginate(tt.rows, tt.limit, tt.sort, tt.desc)
+
+			var ids []int
+			for _, a := range page {
+				ids = append(ids, a.ID)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("ids = %v, want %v", ids, tt.wantIDs)
+				}
+			}
+
+			if tt.wantCursor == nil {
+				if cursor != "" {
+					t.Errorf("cursor = %q, want none on the last page", cursor)
+				}
+				return
+			}
+			got, err := decodeCursor(cursor)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if *got != *tt.wantCursor {
+				t.Errorf("cursor = %+v, want %+v", *got, *tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestHighlightHTML(t *testing.T) {
+	tests := []struct {
+		headline string
+		want     string
+		wantOK   bool
+	}{
+		{headline: "Maria Souza", wantOK: false},
+		{headline: "\x02Maria\x03 Souza", want: "<mark>Maria</mark> Souza", wantOK: true},
+		{
+			headline: "\x02Ana\x03 <script>alert('x')</script> & \"Cia\"",
+			want:     "<mark>Ana</mark> &lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt; &amp; &#34;Cia&#34;",
+			wantOK:   true,
+		},
+	}
+	for _, tt := range tests {
+		got, ok := highlightHTML(tt.headline)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("highlightHTML(%q) = %q, %v, want %q, %v", tt.headline, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSyncToken(t *testing.T) {
+	want := syncToken{XID: 1 << 40, Seq: 987}
+	got, err := decodeSyncToken(encodeSyncToken(want))
+	if err != nil {
+		t.Fatalf("decodeSyncToken: %v", err)
+	}
+	if *got != want {
+		t.Errorf("token = %+v, want %+v", *got, want)
+	}
+
+	for name, token := range map[string]string{
+		"base64 inválido":    "não é base64!",
+		"json inválido":      "bm90IGpzb24",
+		"formato (t, id)":    encodeJSON(t, map[string]interface{}{"t": "2024-01-01T00:00:00Z", "id": 7}),
+		"xid negativo":       encodeJSON(t, map[string]interface{}{"x": -1, "s": 1}),
+		"campo desconhecido": encodeJSON(t, map[string]interface{}{"x": 1, "s": 1, "ids": []int{1}}),
+	} {
+		if _, err := decodeSyncToken(token); !errors.Is(err, models.ErrInvalidQuery) {
+			t.Errorf("%s: decodeSyncToken(%q) error = %v, want ErrInvalidQuery", name, token, err)
+		}
+	}
+}
+
+func encodeJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestPurgeRemovesPII(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := NewAlunoRepository(db)
+
+	const nome, professor = "Zuleica Expurgo Teste", "Prof. Anacleto Expurgo"
+	var numero, professorID int
+	if err := db.QueryRow("INSERT INTO salas (numero, capacidade) VALUES ((SELECT COALESCE(MAX(numero), 0) + 1 FROM salas), 10) RETURNING numero").Scan(&numero); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow("INSERT INTO professores (nome) VALUES ($1) RETURNING id", fmt.Sprintf("%s %d", professor, numero)).Scan(&professorID); err != nil {
+		t.Fatal(err)
+	}
+
+	aluno := &models.Aluno{Nome: nome, Idade: 15, ProfessorID: &professorID, NumeroSala: numero}
+	if err := repo.Create(ctx, aluno); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	aluno.Nome, aluno.Idade = nome+" Alterado", 16
+	if err := repo.Update(ctx, aluno); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := repo.Delete(ctx, aluno.ID, models.AnyVersion); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+
+	var versoes int
+	if err := db.QueryRow("SELECT COUNT(*) FROM alunos_historico WHERE id = $1", aluno.ID).Scan(&versoes); err != nil {
+		t.Fatal(err)
+	}
+	if versoes != 0 {
+		t.Errorf("alunos_historico keeps %d versions of the purged student", versoes)
+	}
+
+	rows, err := db.Query("SELECT operacao, diff::text FROM auditoria WHERE aluno_id = $1 ORDER BY id", aluno.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var operacoes []string
+	for rows.Next() {
+		var operacao, diff string
+		if err := rows.Scan(&operacao, &diff); err != nil {
+			t.Fatal(err)
+		}
+		operacoes = append(operacoes, operacao)
+		if diff != "{}" {
+			t.Errorf("auditoria %s keeps the payload %s", operacao, diff)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{models.OperacaoCriacao, models.OperacaoAlteracao, models.OperacaoRemocao, models.OperacaoExpurgo}
+	if strings.Join(operacoes, ",") != strings.Join(want, ",") {
+		t.Errorf("auditoria operations = %v, want %v", operacoes, want)
+	}
+
+	var restos int
+	err = db.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM auditoria WHERE diff::text LIKE '%' || $1 || '%') +
+		(SELECT COUNT(*) FROM alunos_historico WHERE nome LIKE '%' || $1 || '%') +
+		(SELECT COUNT(*) FROM alunos WHERE nome LIKE '%' || $1 || '%')`, nome).Scan(&restos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restos != 0 {
+		t.Errorf("%d rows still contain the purged student's name", restos)
+	}
+}
+
+func TestGetByIDAsOf(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := NewAlunoRepository(db)
+
+	var numero int
+	if err := db.QueryRow("INSERT INTO salas (numero, capacidade) VALUES ((SELECT COALESCE(MAX(numero), 0) + 1 FROM salas), 10) RETURNING numero").Scan(&numero); err != nil {
+		t.Fatal(err)
+	}
+	var antes time.Time
+	if err := db.QueryRow("SELECT now()").Scan(&antes); err != nil {
+		t.Fatal(err)
+	}
+	aluno := &models.Aluno{Nome: "Ana Histórico", Idade: 15, NumeroSala: numero}
+	if err := repo.Create(ctx, aluno); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	criado := aluno.Version
+	aluno.Idade = 16
+	if err := repo.Update(ctx, aluno); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := repo.GetByIDAsOf(ctx, aluno.ID, antes.Add(-time.Second)); !errors.Is(err, models.ErrAlunoNotFound) {
+		t.Errorf("GetByIDAsOf before the first version error = %v, want ErrAlunoNotFound", err)
+	}
+	got, err := repo.GetByIDAsOf(ctx, aluno.ID, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetByIDAsOf: %v", err)
+	}
+	if got.Version != aluno.Version || got.Idade != 16 {
+		t.Errorf("current version = %d (idade %d), want %d (idade 16)", got.Version, got.Idade, aluno.Version)
+	}
+	if got.Version == criado {
+		t.Errorf("Update did not record a new version")
+	}
+}
+
+// TestCadastroTouchesAlunos verifica que as alterações da sala e do professor que mudam a
+// representação do aluno geram nova versão, auditoria e histórico.
+func TestCadastroTouchesAlunos(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	alunos, salas, professores := NewAlunoRepository(db), NewSalaRepository(db), NewProfessorRepository(db)
+
+	tests := []struct {
+		name      string
+		campo     string
+		operation func(sala *models.Sala, professor *models.Professor) error
+	}{
+		{
+			name:  "sala renumerada",
+			campo: "numero_sala",
+			operation: func(sala *models.Sala, _ *models.Professor) error {
+				var numero int
+				if err := db.QueryRow("SELECT COALESCE(MAX(numero), 0) + 1 FROM salas").Scan(&numero); err != nil {
+					return err
+				}
+				sala.Numero = numero
+				return salas.Update(ctx, sala)
+			},
+		},
+		{
+			name:  "professor renomeado",
+			campo: "nome_professor",
+			operation: func(_ *models.Sala, professor *models.Professor) error {
+				professor.Nome += " Renomeado"
+				return professores.Update(ctx, professor)
+			},
+		},
+		{
+			name:  "professor removido",
+			campo: "professor_id",
+			operation: func(_ *models.Sala, professor *models.Professor) error {
+				return professores.Delete(ctx, professor.ID)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sala := &models.Sala{Capacidade: 10}
+			if err := db.QueryRow("INSERT INTO salas (numero, capacidade) VALUES ((SELECT COALESCE(MAX(numero), 0) + 1 FROM salas), 10) RETURNING id, numero").
+				Scan(&sala.ID, &sala.Numero); err != nil {
+				t.Fatal(err)
+			}
+			professor := &models.Professor{Nome: fmt.Sprintf("Prof. Cadastro %d", sala.Numero)}
+			if err := professores.Create(ctx, professor); err != nil {
+				t.Fatal(err)
+			}
+			aluno := &models.Aluno{Nome: "Ana Cadastro", Idade: 15, ProfessorID: &professor.ID, NumeroSala: sala.Numero}
+			if err := alunos.Create(ctx, aluno); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			if err := tt.operation(sala, professor); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+
+			got, err := alunos.GetByID(ctx, aluno.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Version != aluno.Version+1 {
+				t.Errorf("version = %d, want %d", got.Version, aluno.Version+1)
+			}
+			if !got.UpdatedAt.After(aluno.UpdatedAt) {
+				t.Errorf("updated_at = %v, want after %v", got.UpdatedAt, aluno.UpdatedAt)
+			}
+
+			var diff string
+			err = db.QueryRow("SELECT diff::text FROM auditoria WHERE aluno_id = $1 AND operacao = $2", aluno.ID, models.OperacaoAlteracao).Scan(&diff)
+			if err != nil {
+				t.Fatalf("auditoria: %v", err)
+			}
+			if !strings.Contains(diff, `"`+tt.campo+`"`) {
+				t.Errorf("auditoria diff = %s, want the field %s", diff, tt.campo)
+			}
+
+			var versoes int
+			if err := db.QueryRow("SELECT COUNT(*) FROM alunos_historico WHERE id = $1 AND version = $2", aluno.ID, got.Version).Scan(&versoes); err != nil {
+				t.Fatal(err)
+			}
+			if versoes != 1 {
+				t.Errorf("alunos_historico has %d rows for version %d, want 1", versoes, got.Version)
+			}
+		})
+	}
+}
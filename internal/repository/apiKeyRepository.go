@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+// APIKeyRepository persiste as chaves de API, identificadas na autenticação pelo hash da chave.
+type APIKeyRepository interface {
+	List(ctx context.Context) ([]models.APIKey, error)
+	Create(ctx context.Context, key *models.APIKey, hash []byte) error
+	Revoke(ctx context.Context, id int) error
+	Use(ctx context.Context, hash []byte) (*models.APIKey, error)
+}
+
+type apiKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) APIKeyRepository {
+	return &apiKeyRepository{db}
+}
+
+const apiKeyColumns = "id, nome, prefixo, scopes, criada_por, created_at, expires_at, last_used_at, revoked_at"
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(&key.ID, &key.Nome, &key.Prefixo, pq.Array(&key.Scopes), &key.CriadaPor,
+		&key.CreatedAt, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List retorna todas as chaves, inclusive as revogadas e expiradas, da mais recente para a mais antiga.
+func (r *apiKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	ctx, end := trace(ctx, "apiKey", "List")
+	defer end()
+
+	rows, err := queryContext(ctx, r.db, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey, hash []byte) error {
+	ctx, end := trace(ctx, "apiKey", "Create")
+	defer end()
+
+	return queryRowContext(ctx, r.db, `INSERT INTO api_keys (nome, prefixo, hash, scopes, criada_por, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`,
+		key.Nome, key.Prefixo, hash, pq.Array(key.Scopes), key.CriadaPor, key.ExpiresAt).Scan(&key.ID, &key.CreatedAt)
+}
+
+// Revoke revoga a chave. Revogar uma chave já revogada mantém a data da primeira revogação.
+func (r *apiKeyRepository) Revoke(ctx context.Context, id int) error {
+	ctx, end := trace(ctx, "apiKey", "Revoke")
+	defer end()
+
+	result, err := execContext(ctx, r.db, "UPDATE api_keys SET revoked_at = COALESCE(revoked_at, now()) WHERE id = $1", id)
+	return checkAffected(result, err, models.ErrAPIKeyNotFound)
+}
+
+// apiKeyUseInterval é o intervalo mínimo entre as gravações de last_used_at de uma chave, para
+// que cada requisição autenticada por ela não custe uma escrita no banco.
+const apiKeyUseInterval = time.Minute
+
+// Use retorna a chave válida (não revogada nem expirada) com o hash informado e registra o seu
+// uso em last_used_at, com a precisão de apiKeyUseInterval, ou ErrAPIKeyNotFound se não houver.
+func (r *apiKeyRepository) Use(ctx context.Context, hash []byte) (*models.APIKey, error) {
+	ctx, end := trace(ctx, "apiKey", "Use")
+	defer end()
+
+	key, err := scanAPIKey(queryRowContext(ctx, r.db, `SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > now())`, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.LastUsedAt != nil && time.Since(*key.LastUsedAt) < apiKeyUseInterval {
+		return key, nil
+	}
+
+	// A condição repetida no UPDATE evita gravações duplicadas de requisições simultâneas
+	err = queryRowContext(ctx, r.db, `UPDATE api_keys SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - make_interval(secs => $2))
+		RETURNING last_used_at`, key.ID, apiKeyUseInterval.Seconds()).Scan(&key.LastUsedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return key, nil
+	}
+	return key, err
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+func TestAPIKeyUseThrottlesLastUsedAt(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := NewAPIKeyRepository(db)
+
+	hash := []byte(fmt.Sprintf("hash-de-teste-%d", time.Now().UnixNano()))
+	key := &models.APIKey{Nome: "BI", Prefixo: "dcc_teste", Scopes: []string{models.ScopeAlunosRead}, CriadaPor: "teste"}
+	if err := repo.Create(ctx, key, hash); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	first, err := repo.Use(ctx, hash)
+	if err != nil {
+		t.Fatalf("Use: %v", err)
+	}
+	if first.LastUsedAt == nil {
+		t.Fatal("first Use did not record last_used_at")
+	}
+	second, err := repo.Use(ctx, hash)
+	if err != nil {
+		t.Fatalf("Use: %v", err)
+	}
+	if second.LastUsedAt == nil || !second.LastUsedAt.Equal(*first.LastUsedAt) {
+		t.Errorf("last_used_at = %v after a second use within %s, want %v", second.LastUsedAt, apiKeyUseInterval, first.LastUsedAt)
+	}
+
+	if _, err := db.Exec("UPDATE api_keys SET last_used_at = now() - interval '2 minutes' WHERE id = $1", key.ID); err != nil {
+		t.Fatal(err)
+	}
+	third, err := repo.Use(ctx, hash)
+	if err != nil {
+		t.Fatalf("Use: %v", err)
+	}
+	if third.LastUsedAt == nil || time.Since(*third.LastUsedAt) > apiKeyUseInterval {
+		t.Errorf("last_used_at = %v, want it refreshed once older than %s", third.LastUsedAt, apiKeyUseInterval)
+	}
+
+	if err := repo.Revoke(ctx, key.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, err := repo.Use(ctx, hash); !errors.Is(err, models.ErrAPIKeyNotFound) {
+		t.Errorf("Use of a revoked key error = %v, want ErrAPIKeyNotFound", err)
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/actor"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+type AuditoriaRepository interface {
+	List(ctx context.Context, query models.AuditoriaQuery) (*models.AuditoriaPage, error)
+}
+
+type auditoriaRepository struct {
+	db *sql.DB
+}
+
+func NewAuditoriaRepository(db *sql.DB) AuditoriaRepository {
+	return &auditoriaRepository{db}
+}
+
+// alunoSnapshot é o estado do aluno registrado na auditoria: as colunas da tabela alunos, exceto
+// as atualizadas a cada alteração (version e updated_at) e a coluna de busca.
+const alunoSnapshot = "to_jsonb(alunos) - 'busca' - 'version' - 'updated_at'"
+
+// Condições de snapshotAluno.
+const (
+	alunoAtivo    = "deleted_at IS NULL"
+	alunoRemovido = "deleted_at IS NOT NULL"
+)
+
+// snapshotAluno bloqueia o aluno até o fim da transação e retorna o seu estado atual, ou
+// ErrAlunoNotFound se não houver aluno com o id que atenda à condição.
+func snapshotAluno(ctx context.Context, tx *sql.Tx, id int, cond string) ([]byte, error) {
+	var snapshot []byte
+	err := queryRowContext(ctx, tx, "SELECT "+alunoSnapshot+" FROM alunos WHERE id = $1 AND "+cond+" FOR UPDATE", id).Scan(&snapshot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAlunoNotFound
+	}
+	return snapshot, err
+}
+
+// alunoSnapshotCadastro acrescenta a alunoSnapshot o nome do professor, para que as alterações
+// dos cadastros referenciados pelo aluno (como o professor renomeado) apareçam na auditoria.
+const alunoSnapshotCadastro = "(" + alunoSnapshot + ") || jsonb_build_object('nome_professor', (SELECT p.nome FROM professores p WHERE p.id = alunos.professor_id))"
+
+// snapshotAlunos bloqueia, em ordem de id, os alunos (inclusive os removidos) que atendem à
+// condição, com um único parâmetro, e retorna o estado de cada um com alunoSnapshotCadastro.
+func snapshotAlunos(ctx context.Context, tx *sql.Tx, cond string, arg interface{}) (map[int][]byte, error) {
+	rows, err := queryContext(ctx, tx, "SELECT id, "+alunoSnapshotCadastro+" FROM alunos WHERE "+cond+" ORDER BY id FOR UPDATE", arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := map[int][]byte{}
+	for rows.Next() {
+		var id int
+		var snapshot []byte
+		if err := rows.Scan(&id, &snapshot); err != nil {
+			return nil, err
+		}
+		snapshots[id] = snapshot
+	}
+	return snapshots, rows.Err()
+}
+
+// recordAuditoria registra, na transação da alteração, a operação do ator do contexto com os
+// campos que mudaram entre os estados antes e depois (nil quando o aluno não existia).
+// Operações que não mudaram nenhum campo não são registradas.
+func recordAuditoria(ctx context.Context, tx *sql.Tx, alunoID int, operacao string, antes, depois []byte) error {
+	diff, err := diffSnapshots(antes, depois)
+	if err != nil || len(diff) == 0 {
+		return err
+	}
+	data, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+	_, err = execContext(ctx, tx, "INSERT INTO auditoria (aluno_id, ator, operacao, diff) VALUES ($1, $2, $3, $4)",
+		alunoID, actor.FromContext(ctx), operacao, data)
+	return err
+}
+
+// diffSnapshots compara dois estados (objetos JSON) campo a campo. O jsonb do Postgres tem
+// representação textual canônica, então valores iguais têm o mesmo texto.
+func diffSnapshots(antes, depois []byte) (map[string]models.Alteracao, error) {
+	var before, after map[string]json.RawMessage
+	if antes != nil {
+		if err := json.Unmarshal(antes, &before); err != nil {
+			return nil, err
+		}
+	}
+	if depois != nil {
+		if err := json.Unmarshal(depois, &after); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := map[string]models.Alteracao{}
+	for campo, valor := range before {
+		if novo, ok := after[campo]; !ok || !bytes.Equal(valor, novo) {
+			diff[campo] = models.Alteracao{Antes: valor, Depois: after[campo]}
+		}
+	}
+	for campo, novo := range after {
+		if _, ok := before[campo]; !ok {
+			diff[campo] = models.Alteracao{Depois: novo}
+		}
+	}
+	return diff, nil
+}
+
+func auditoriaFilters(query models.AuditoriaQuery) *whereBuilder {
+	where := &whereBuilder{}
+	if query.AlunoID != nil {
+		where.add("aluno_id = ?", *query.AlunoID)
+	}
+	if query.Ator != "" {
+		where.add("ator = ?", query.Ator)
+	}
+	if query.Operacao != "" {
+		where.add("operacao = ?", query.Operacao)
+	}
+	if query.Desde != nil {
+		where.add("data >= ?", *query.Desde)
+	}
+	if query.Ate != nil {
+		where.add("data < ?", *query.Ate)
+	}
+	return where
+}
+
+func (r *auditoriaRepository) List(ctx context.Context, query models.AuditoriaQuery) (*models.AuditoriaPage, error) {
+	ctx, end := trace(ctx, "auditoria", "List")
+	defer end()
+
+	where := auditoriaFilters(query)
+
+	var total int
+	if err := queryRowContext(ctx, r.db, "SELECT COUNT(*) FROM auditoria"+where.String(), where.args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	args := append(where.args, query.Limit, query.Offset)
+	rows, err := queryContext(ctx, r.db, "SELECT id, aluno_id, ator, operacao, data, diff FROM auditoria"+where.String()+
+		" ORDER BY data DESC, id DESC LIMIT $"+strconv.Itoa(len(args)-1)+" OFFSET $"+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entradas := []models.Auditoria{}
+	for rows.Next() {
+		var entrada models.Auditoria
+		var diff []byte
+		if err := rows.Scan(&entrada.ID, &entrada.AlunoID, &entrada.Ator, &entrada.Operacao, &entrada.Data, &diff); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(diff, &entrada.Diff); err != nil {
+			return nil, err
+		}
+		entradas = append(entradas, entrada)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.AuditoriaPage{
+		Data: entradas,
+		Meta: models.PageMeta{Total: total, Limit: query.Limit, Offset: query.Offset},
+	}, nil
+}
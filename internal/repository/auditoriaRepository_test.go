@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	// alteracao é uma models.Alteracao com os valores como texto; "" é o campo ausente.
+	type alteracao struct{ Antes, Depois string }
+
+	tests := []struct {
+		name    string
+		antes   string // "" é o aluno que não existia (criação) ou deixou de existir (expurgo)
+		depois  string
+		want    map[string]alteracao
+		wantErr bool
+	}{
+		{
+			name:   "criação",
+			depois: `{"id": 1, "nome": "Ana", "professor_id": null}`,
+			want: map[string]alteracao{
+				"id":           {Depois: "1"},
+				"nome":         {Depois: `"Ana"`},
+				"professor_id": {Depois: "null"},
+			},
+		},
+		{
+			name:  "expurgo",
+			antes: `{"id": 1, "nome": "Ana"}`,
+			want: map[string]alteracao{
+				"id":   {Antes: "1"},
+				"nome": {Antes: `"Ana"`},
+			},
+		},
+		{
+			name:   "campo alterado",
+			antes:  `{"id": 1, "nome": "Ana", "idade": 15}`,
+			depois: `{"id": 1, "nome": "Ana Maria", "idade": 15}`,
+			want:   map[string]alteracao{"nome": {Antes: `"Ana"`, Depois: `"Ana Maria"`}},
+		},
+		{
+			name:   "campo para null",
+			antes:  `{"id": 1, "professor_id": 3}`,
+			depois: `{"id": 1, "professor_id": null}`,
+			want:   map[string]alteracao{"professor_id": {Antes: "3", Depois: "null"}},
+		},
+		{
+			name:   "campo acrescentado",
+			antes:  `{"id": 1}`,
+			depois: `{"id": 1, "nome_professor": "Prof. Silva"}`,
+			want:   map[string]alteracao{"nome_professor": {Depois: `"Prof. Silva"`}},
+		},
+		{
+			name:   "campo removido",
+			antes:  `{"id": 1, "nome_professor": "Prof. Silva"}`,
+			depois: `{"id": 1}`,
+			want:   map[string]alteracao{"nome_professor": {Antes: `"Prof. Silva"`}},
+		},
+		{
+			name:   "sem alterações",
+			antes:  `{"id": 1, "nome": "Ana"}`,
+			depois: `{"id": 1, "nome": "Ana"}`,
+			want:   map[string]alteracao{},
+		},
+		{name: "sem estados", want: map[string]alteracao{}},
+		{name: "antes inválido", antes: `{"id":`, depois: `{"id": 1}`, wantErr: true},
+		{name: "depois não é objeto", antes: `{"id": 1}`, depois: `[1]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var antes, depois []byte
+			if tt.antes != "" {
+				antes = []byte(tt.antes)
+			}
+			if tt.depois != "" {
+				depois = []byte(tt.depois)
+			}
+
+			diff, err := diffSnapshots(antes, depois)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("diffSnapshots error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := map[string]alteracao{}
+			for campo, a := range diff {
+				got[campo] = alteracao{Antes: string(a.Antes), Depois: string(a.Depois)}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffSnapshots = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
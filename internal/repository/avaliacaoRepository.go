@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/lib/pq"
+)
+
+type AvaliacaoRepository interface {
+	ListByAluno(ctx context.Context, alunoID int) ([]models.Avaliacao, error)
+	ListByAlunos(ctx context.Context, alunoIDs []int) (map[int][]models.Avaliacao, error)
+	GetByID(ctx context.Context, alunoID, id int) (*models.Avaliacao, error)
+	Create(ctx context.Context, avaliacao *models.Avaliacao) error
+	Update(ctx context.Context, avaliacao *models.Avaliacao) error
+	Delete(ctx context.Context, alunoID, id int) error
+}
+
+const (
+	avaliacaoColumns = "av.id, av.aluno_id, av.disciplina_id, d.nome, av.bimestre, av.descricao, av.nota, av.recuperacao"
+	avaliacaoFrom    = " FROM avaliacoes av JOIN disciplinas d ON d.id = av.disciplina_id"
+)
+
+type avaliacaoRepository struct {
+	db *sql.DB
+}
+
+func NewAvaliacaoRepository(db *sql.DB) AvaliacaoRepository {
+	return &avaliacaoRepository{db}
+}
+
+func scanAvaliacao(row rowScanner) (*models.Avaliacao, error) {
+	var av models.Avaliacao
+	err := row.Scan(&av.ID, &av.AlunoID, &av.DisciplinaID, &av.NomeDisciplina, &av.Bimestre, &av.Descricao, &av.Nota, &av.Recuperacao)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAvaliacaoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &av, nil
+}
+
+// avaliacaoWriteError traduz as violações de constraint de avaliacoes em erros de domínio.
+func avaliacaoWriteError(err error) error {
+	switch {
+	case isUniqueViolation(err):
+		return models.ErrDuplicateAvaliacao
+	case isForeignKeyViolation(err):
+		return models.ErrDisciplinaNotFound
+	default:
+		return err
+	}
+}
+
+func (r *avaliacaoRepository) ListByAluno(ctx context.Context, alunoID int) ([]models.Avaliacao, error) {
+	ctx, end := trace(ctx, "avaliacao", "ListByAluno")
+	defer end()
+
+	rows, err := queryContext(ctx, r.db, "SELECT "+avaliacaoColumns+avaliacaoFrom+" WHERE av.aluno_id = $1 ORDER BY d.nome, av.bimestre, av.descricao", alunoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	avaliacoes := []models.Avaliacao{}
+	for rows.Next() {
+		av, err := scanAvaliacao(rows)
+		if err != nil {
+			return nil, err
+		}
+		avaliacoes = append(avaliacoes, *av)
+	}
+
+	return avaliacoes, rows.Err()
+}
+
+// ListByAlunos retorna as avaliações de vários alunos de uma só vez, agrupadas pelo id do aluno.
+func (r *avaliacaoRepository) ListByAlunos(ctx context.Context, alunoIDs []int) (map[int][]models.Avaliacao, error) {
+	ctx, end := trace(ctx, "avaliacao", "ListByAlunos")
+	defer end()
+
+	rows, err := queryContext(ctx, r.db, "SELECT "+avaliacaoColumns+avaliacaoFrom+" WHERE av.aluno_id = ANY($1) ORDER BY av.aluno_id, d.nome, av.bimestre, av.descricao", pq.Array(alunoIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	avaliacoes := make(map[int][]models.Avaliacao, len(alunoIDs))
+	for rows.Next() {
+		av, err := scanAvaliacao(rows)
+		if err != nil {
+			return nil, err
+		}
+		avaliacoes[av.AlunoID] = append(avaliacoes[av.AlunoID], *av)
+	}
+
+	return avaliacoes, rows.Err()
+}
+
+func (r *avaliacaoRepository) GetByID(ctx context.Context, alunoID, id int) (*models.Avaliacao, error) {
+	ctx, end := trace(ctx, "avaliacao", "GetByID")
+	defer end()
+
+	return scanAvaliacao(queryRowContext(ctx, r.db, "SELECT "+avaliacaoColumns+avaliacaoFrom+" WHERE av.aluno_id = $1 AND av.id = $2", alunoID, id))
+}
+
+func (r *avaliacaoRepository) Create(ctx context.Context, avaliacao *models.Avaliacao) error {
+	ctx, end := trace(ctx, "avaliacao", "Create")
+	defer end()
+
+	err := queryRowContext(ctx, r.db, `WITH nova AS (
+			INSERT INTO avaliacoes (aluno_id, disciplina_id, bimestre, descricao, nota, recuperacao) VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, disciplina_id
+		)
+		SELECT nova.id, d.nome FROM nova JOIN disciplinas d ON d.id = nova.disciplina_id`,
+		avaliacao.AlunoID, avaliacao.DisciplinaID, avaliacao.Bimestre, avaliacao.Descricao, avaliacao.Nota, avaliacao.Recuperacao).Scan(&avaliacao.ID, &avaliacao.NomeDisciplina)
+	return avaliacaoWriteError(err)
+}
+
+func (r *avaliacaoRepository) Update(ctx context.Context, avaliacao *models.Avaliacao) error {
+	ctx, end := trace(ctx, "avaliacao", "Update")
+	defer end()
+
+	err := queryRowContext(ctx, r.db, `WITH alterada AS (
+			UPDATE avaliacoes SET disciplina_id = $1, bimestre = $2, descricao = $3, nota = $4, recuperacao = $5
+			WHERE aluno_id = $6 AND id = $7
+			RETURNING disciplina_id
+		)
+		SELECT d.nome FROM alterada JOIN disciplinas d ON d.id = alterada.disciplina_id`,
+		avaliacao.DisciplinaID, avaliacao.Bimestre, avaliacao.Descricao, avaliacao.Nota, avaliacao.Recuperacao, avaliacao.AlunoID, avaliacao.ID).Scan(&avaliacao.NomeDisciplina)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrAvaliacaoNotFound
+	}
+	return avaliacaoWriteError(err)
+}
+
+func (r *avaliacaoRepository) Delete(ctx context.Context, alunoID, id int) error {
+	ctx, end := trace(ctx, "avaliacao", "Delete")
+	defer end()
+
+	result, err := execContext(ctx, r.db, "DELETE FROM avaliacoes WHERE aluno_id = $1 AND id = $2", alunoID, id)
+	return checkAffected(result, err, models.ErrAvaliacaoNotFound)
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+type DisciplinaRepository interface {
+	GetAll(ctx context.Context) ([]models.Disciplina, error)
+	GetByID(ctx context.Context, id int) (*models.Disciplina, error)
+	Create(ctx context.Context, disciplina *models.Disciplina) error
+	Update(ctx context.Context, disciplina *models.Disciplina) error
+	Delete(ctx context.Context, id int) error
+}
+
+type disciplinaRepository struct {
+	db *sql.DB
+}
+
+func NewDisciplinaRepository(db *sql.DB) DisciplinaRepository {
+	return &disciplinaRepository{db}
+}
+
+func (r *disciplinaRepository) GetAll(ctx context.Context) ([]models.Disciplina, error) {
+	ctx, end := trace(ctx, "disciplina", "GetAll")
+	defer end()
+
+	rows, err := queryContext(ctx, r.db, "SELECT id, nome FROM disciplinas ORDER BY nome, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disciplinas := []models.Disciplina{}
+	for rows.Next() {
+		var disciplina models.Disciplina
+		if err := rows.Scan(&disciplina.ID, &disciplina.Nome); err != nil {
+			return nil, err
+		}
+		disciplinas = append(disciplinas, disciplina)
+	}
+
+	return disciplinas, rows.Err()
+}
+
+func (r *disciplinaRepository) GetByID(ctx context.Context, id int) (*models.Disciplina, error) {
+	ctx, end := trace(ctx, "disciplina", "GetByID")
+	defer end()
+
+	var disciplina models.Disciplina
+	err := queryRowContext(ctx, r.db, "SELECT id, nome FROM disciplinas WHERE id = $1", id).Scan(&disciplina.ID, &disciplina.Nome)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrDisciplinaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &disciplina, nil
+}
+
+func (r *disciplinaRepository) Create(ctx context.Context, disciplina *models.Disciplina) error {
+	ctx, end := trace(ctx, "disciplina", "Create")
+	defer end()
+
+	err := queryRowContext(ctx, r.db, "INSERT INTO disciplinas (nome) VALUES ($1) RETURNING id", disciplina.Nome).Scan(&disciplina.ID)
+	if isUniqueViolation(err) {
+		return models.ErrDuplicateDisciplina
+	}
+	return err
+}
+
+func (r *disciplinaRepository) Update(ctx context.Context, disciplina *models.Disciplina) error {
+	ctx, end := trace(ctx, "disciplina", "Update")
+	defer end()
+
+	result, err := execContext(ctx, r.db, "UPDATE disciplinas SET nome = $1 WHERE id = $2", disciplina.Nome, disciplina.ID)
+	if isUniqueViolation(err) {
+		return models.ErrDuplicateDisciplina
+	}
+	return checkAffected(result, err, models.ErrDisciplinaNotFound)
+}
+
+func (r *disciplinaRepository) Delete(ctx context.Context, id int) error {
+	ctx, end := trace(ctx, "disciplina", "Delete")
+	defer end()
+
+	result, err := execContext(ctx, r.db, "DELETE FROM disciplinas WHERE id = $1", id)
+	if isForeignKeyViolation(err) {
+		return models.ErrDisciplinaInUse
+	}
+	return checkAffected(result, err, models.ErrDisciplinaNotFound)
+}
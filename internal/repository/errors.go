@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+func hasPQCode(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
+
+func isUniqueViolation(err error) bool {
+	return hasPQCode(err, uniqueViolation)
+}
+
+func isForeignKeyViolation(err error) bool {
+	return hasPQCode(err, foreignKeyViolation)
+}
+
+// checkAffected completa um Exec de UPDATE ou DELETE: retorna o erro do comando, se houver,
+// ou notFound quando nenhuma linha foi afetada.
+func checkAffected(result sql.Result, err error, notFound error) error {
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// situacaoSemNotas é o rótulo situacao dos alunos que ainda não têm notas (situacao nula).
+const situacaoSemNotas = "sem_notas"
+
+// indicadoresTimeout limita a espera pelas consultas dos indicadores em cada coleta.
+const indicadoresTimeout = 5 * time.Second
+
+// indicadoresCollector publica os indicadores do cadastro, consultados no banco a cada coleta.
+type indicadoresCollector struct {
+	db          *sql.DB
+	alunos      *prometheus.Desc
+	removidos   *prometheus.Desc
+	professores *prometheus.Desc
+	salas       *prometheus.Desc
+	disciplinas *prometheus.Desc
+}
+
+func NewIndicadoresCollector(db *sql.DB) prometheus.Collector {
+	return &indicadoresCollector{
+		db:          db,
+		alunos:      prometheus.NewDesc("alunos_cadastrados", "Alunos cadastrados (não removidos), por situação.", []string{"situacao"}, nil),
+		removidos:   prometheus.NewDesc("alunos_removidos", "Alunos removidos que aguardam o expurgo.", nil, nil),
+		professores: prometheus.NewDesc("professores_cadastrados", "Professores cadastrados.", nil, nil),
+		salas:       prometheus.NewDesc("salas_cadastradas", "Salas cadastradas.", nil, nil),
+		disciplinas: prometheus.NewDesc("disciplinas_cadastradas", "Disciplinas cadastradas.", nil, nil),
+	}
+}
+
+func (c *indicadoresCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.alunos
+	ch <- c.removidos
+	ch <- c.professores
+	ch <- c.salas
+	ch <- c.disciplinas
+}
+
+// Collect publica os indicadores ou, se o banco falhar, uma métrica inválida com o erro, que
+// omite apenas os indicadores da coleta.
+func (c *indicadoresCollector) Collect(ch chan<- prometheus.Metric) {
+	if err := c.collect(ch); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.alunos, err)
+	}
+}
+
+func (c *indicadoresCollector) collect(ch chan<- prometheus.Metric) error {
+	// Sem span: as coletas periódicas do Prometheus só gerariam rastros sem interesse
+	defer observe("indicadores", "Collect")()
+
+	ctx, cancel := context.WithTimeout(context.Background(), indicadoresTimeout)
+	defer cancel()
+
+	// Todas as situações são publicadas, mesmo sem alunos, para que as séries não desapareçam
+	porSituacao := map[string]int{
+		models.SituacaoAprovado:    0,
+		models.SituacaoRecuperacao: 0,
+		models.SituacaoReprovado:   0,
+		situacaoSemNotas:           0,
+	}
+	rows, err := c.db.QueryContext(ctx, "SELECT COALESCE(situacao, $1), COUNT(*) FROM alunos WHERE deleted_at IS NULL GROUP BY 1", situacaoSemNotas)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var situacao string
+		var total int
+		if err := rows.Scan(&situacao, &total); err != nil {
+			return err
+		}
+		porSituacao[situacao] = total
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var removidos, professores, salas, disciplinas int
+	err = c.db.QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*) FROM alunos WHERE deleted_at IS NOT NULL),
+		(SELECT COUNT(*) FROM professores),
+		(SELECT COUNT(*) FROM salas),
+		(SELECT COUNT(*) FROM disciplinas)`).Scan(&removidos, &professores, &salas, &disciplinas)
+	if err != nil {
+		return err
+	}
+
+	for _, situacao := range []string{models.SituacaoAprovado, models.SituacaoRecuperacao, models.SituacaoReprovado, situacaoSemNotas} {
+		ch <- prometheus.MustNewConstMetric(c.alunos, prometheus.GaugeValue, float64(porSituacao[situacao]), situacao)
+	}
+	ch <- prometheus.MustNewConstMetric(c.removidos, prometheus.GaugeValue, float64(removidos))
+	ch <- prometheus.MustNewConstMetric(c.professores, prometheus.GaugeValue, float64(professores))
+	ch <- prometheus.MustNewConstMetric(c.salas, prometheus.GaugeValue, float64(salas))
+	ch <- prometheus.MustNewConstMetric(c.disciplinas, prometheus.GaugeValue, float64(disciplinas))
+	return nil
+}
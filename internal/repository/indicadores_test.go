@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestIndicadoresCollector(t *testing.T) {
+	db := testDB(t)
+	c := NewIndicadoresCollector(db)
+
+	// 4 situações em alunos_cadastrados e uma série em cada um dos outros indicadores
+	if n := testutil.CollectAndCount(c); n != 8 {
+		t.Errorf("series = %d, want 8", n)
+	}
+	if problems, err := testutil.CollectAndLint(c); err != nil || len(problems) > 0 {
+		t.Errorf("lint = %v, %v", problems, err)
+	}
+}
+
+func TestIndicadoresCollectorDatabaseError(t *testing.T) {
+	db, err := sql.Open("postgres", "postgres://localhost/indisponivel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(NewIndicadoresCollector(db))
+	families, err := registry.Gather()
+	if err == nil {
+		t.Fatal("Gather did not report the database error")
+	}
+	if len(families) != 0 {
+		t.Errorf("Gather returned %d families, want none after the error", len(families))
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "repository_query_duration_seconds",
+	Help:    "Duração dos métodos dos repositórios, incluindo todas as consultas e a transação de cada um.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"repository", "method"})
+
+func init() {
+	prometheus.MustRegister(queryDuration)
+}
+
+// observe mede a duração de um método do repositório, a partir da chamada até a execução da
+// função retornada: defer observe("aluno", "List")().
+func observe(repository, method string) func() {
+	start := time.Now()
+	return func() {
+		queryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+type ProfessorRepository interface {
+	GetAll(ctx context.Context) ([]models.Professor, error)
+	GetByID(ctx context.Context, id int) (*models.Professor, error)
+	FindByNome(ctx context.Context, nome string) (*models.Professor, error)
+	Create(ctx context.Context, professor *models.Professor) error
+	Update(ctx context.Context, professor *models.Professor) error
+	Delete(ctx context.Context, id int) error
+}
+
+type professorRepository struct {
+	db *sql.DB
+}
+
+func NewProfessorRepository(db *sql.DB) ProfessorRepository {
+	return &professorRepository{db}
+}
+
+func (r *professorRepository) GetAll(ctx context.Context) ([]models.Professor, error) {
+	ctx, end := trace(ctx, "professor", "GetAll")
+	defer end()
+
+	rows, err := queryContext(ctx, r.db, "SELECT id, nome FROM professores ORDER BY nome, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	professores := []models.Professor{}
+	for rows.Next() {
+		var professor models.Professor
+		if err := rows.Scan(&professor.ID, &professor.Nome); err != nil {
+			return nil, err
+		}
+		professores = append(professores, professor)
+	}
+
+	return professores, rows.Err()
+}
+
+func (r *professorRepository) GetByID(ctx context.Context, id int) (*models.Professor, error) {
+	ctx, end := trace(ctx, "professor", "GetByID")
+	defer end()
+
+	var professor models.Professor
+	err := queryRowContext(ctx, r.db, "SELECT id, nome FROM professores WHERE id = $1", id).Scan(&professor.ID, &professor.Nome)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrProfessorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &professor, nil
+}
+
+// FindByNome retorna o professor cujo nome normalizado (sem acentos, maiúsculas e título)
+// coincide com nome.
+func (r *professorRepository) FindByNome(ctx context.Context, nome string) (*models.Professor, error) {
+	ctx, end := trace(ctx, "professor", "FindByNome")
+	defer end()
+
+	var professor models.Professor
+	err := queryRowContext(ctx, r.db, "SELECT id, nome FROM professores WHERE normalizar_nome_professor(nome) = normalizar_nome_professor($1)", nome).
+		Scan(&professor.ID, &professor.Nome)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrProfessorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &professor, nil
+}
+
+func (r *professorRepository) Create(ctx context.Context, professor *models.Professor) error {
+	ctx, end := trace(ctx, "professor", "Create")
+	defer end()
+
+	err := queryRowContext(ctx, r.db, "INSERT INTO professores (nome) VALUES ($1) RETURNING id", professor.Nome).Scan(&professor.ID)
+	if isUniqueViolation(err) {
+		return models.ErrDuplicateProfessor
+	}
+	return err
+}
+
+// Update e Delete também alteram os alunos do professor, que exibem o seu nome e, com a
+// remoção, ficam sem professor (ON DELETE SET NULL): eles são registrados com touchAlunos.
+
+func (r *professorRepository) Update(ctx context.Context, professor *models.Professor) error {
+	ctx, end := trace(ctx, "professor", "Update")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes, err := snapshotAlunos(ctx, tx, "professor_id = $1", professor.ID)
+		if err != nil {
+			return err
+		}
+		result, err := execContext(ctx, tx, "UPDATE professores SET nome = $1 WHERE id = $2", professor.Nome, professor.ID)
+		if isUniqueViolation(err) {
+			return models.ErrDuplicateProfessor
+		}
+		if err := checkAffected(result, err, models.ErrProfessorNotFound); err != nil {
+			return err
+		}
+		return touchAlunos(ctx, tx, antes)
+	})
+}
+
+func (r *professorRepository) Delete(ctx context.Context, id int) error {
+	ctx, end := trace(ctx, "professor", "Delete")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes, err := snapshotAlunos(ctx, tx, "professor_id = $1", id)
+		if err != nil {
+			return err
+		}
+		result, err := execContext(ctx, tx, "DELETE FROM professores WHERE id = $1", id)
+		if err := checkAffected(result, err, models.ErrProfessorNotFound); err != nil {
+			return err
+		}
+		return touchAlunos(ctx, tx, antes)
+	})
+}
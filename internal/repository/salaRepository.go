@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+type SalaRepository interface {
+	GetAll(ctx context.Context) ([]models.Sala, error)
+	GetByID(ctx context.Context, id int) (*models.Sala, error)
+	GetByNumero(ctx context.Context, numero int) (*models.Sala, error)
+	Create(ctx context.Context, sala *models.Sala) error
+	Update(ctx context.Context, sala *models.Sala) error
+	Delete(ctx context.Context, id int) error
+}
+
+const salaColumns = "s.id, s.numero, s.predio, s.capacidade, (SELECT COUNT(*) FROM alunos a WHERE a.numero_sala = s.numero AND a.deleted_at IS NULL)"
+
+type salaRepository struct {
+	db *sql.DB
+}
+
+func NewSalaRepository(db *sql.DB) SalaRepository {
+	return &salaRepository{db}
+}
+
+func scanSala(row rowScanner) (*models.Sala, error) {
+	var sala models.Sala
+	err := row.Scan(&sala.ID, &sala.Numero, &sala.Predio, &sala.Capacidade, &sala.Ocupacao)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSalaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sala, nil
+}
+
+func (r *salaRepository) GetAll(ctx context.Context) ([]models.Sala, error) {
+	ctx, end := trace(ctx, "sala", "GetAll")
+	defer end()
+
+	rows, err := queryContext(ctx, r.db, "SELECT "+salaColumns+" FROM salas s ORDER BY s.numero")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	salas := []models.Sala{}
+	for rows.Next() {
+		sala, err := scanSala(rows)
+		if err != nil {
+			return nil, err
+		}
+		salas = append(salas, *sala)
+	}
+
+	return salas, rows.Err()
+}
+
+func (r *salaRepository) GetByID(ctx context.Context, id int) (*models.Sala, error) {
+	ctx, end := trace(ctx, "sala", "GetByID")
+	defer end()
+
+	return scanSala(queryRowContext(ctx, r.db, "SELECT "+salaColumns+" FROM salas s WHERE s.id = $1", id))
+}
+
+func (r *salaRepository) GetByNumero(ctx context.Context, numero int) (*models.Sala, error) {
+	ctx, end := trace(ctx, "sala", "GetByNumero")
+	defer end()
+
+	return scanSala(queryRowContext(ctx, r.db, "SELECT "+salaColumns+" FROM salas s WHERE s.numero = $1", numero))
+}
+
+func (r *salaRepository) Create(ctx context.Context, sala *models.Sala) error {
+	ctx, end := trace(ctx, "sala", "Create")
+	defer end()
+
+	err := queryRowContext(ctx, r.db, "INSERT INTO salas (numero, predio, capacidade) VALUES ($1, $2, $3) RETURNING id",
+		sala.Numero, sala.Predio, sala.Capacidade).Scan(&sala.ID)
+	if isUniqueViolation(err) {
+		return models.ErrDuplicateSala
+	}
+	return err
+}
+
+// Update bloqueia a sala antes de conferir a ocupação, como reservarVaga ao matricular um aluno:
+// a capacidade nova não pode ficar abaixo dos alunos matriculados (models.ErrSalaCapacity).
+// Ao renumerar a sala, a chave estrangeira propaga o número aos alunos, registrados com
+// touchAlunos. Eles são bloqueados antes da sala, na mesma ordem das alterações do aluno.
+func (r *salaRepository) Update(ctx context.Context, sala *models.Sala) error {
+	ctx, end := trace(ctx, "sala", "Update")
+	defer end()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		antes := map[int][]byte{}
+		lockAlunos := func(numero int) error {
+			alunos, err := snapshotAlunos(ctx, tx, "numero_sala = $1", numero)
+			for id, snapshot := range alunos {
+				if _, ok := antes[id]; !ok {
+					antes[id] = snapshot
+				}
+			}
+			return err
+		}
+
+		var numero int
+		err := queryRowContext(ctx, tx, "SELECT numero FROM salas WHERE id = $1", sala.ID).Scan(&numero)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrSalaNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if numero != sala.Numero {
+			if err := lockAlunos(numero); err != nil {
+				return err
+			}
+		}
+
+		// A sala pode ter sido renumerada por outra transação antes do bloqueio
+		err = queryRowContext(ctx, tx, "SELECT numero FROM salas WHERE id = $1 FOR UPDATE", sala.ID).Scan(&numero)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrSalaNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if numero != sala.Numero {
+			if err := lockAlunos(numero); err != nil {
+				return err
+			}
+		}
+
+		var ocupacao int
+		err = queryRowContext(ctx, tx, "SELECT COUNT(*) FROM alunos WHERE numero_sala = $1 AND deleted_at IS NULL", numero).Scan(&ocupacao)
+		if err != nil {
+			return err
+		}
+		if sala.Capacidade < ocupacao {
+			return models.ErrSalaCapacity
+		}
+
+		result, err := execContext(ctx, tx, "UPDATE salas SET numero = $1, predio = $2, capacidade = $3 WHERE id = $4",
+			sala.Numero, sala.Predio, sala.Capacidade, sala.ID)
+		if isUniqueViolation(err) {
+			return models.ErrDuplicateSala
+		}
+		if err := checkAffected(result, err, models.ErrSalaNotFound); err != nil {
+			return err
+		}
+		sala.Ocupacao = ocupacao
+		return touchAlunos(ctx, tx, antes)
+	})
+}
+
+func (r *salaRepository) Delete(ctx context.Context, id int) error {
+	ctx, end := trace(ctx, "sala", "Delete")
+	defer end()
+
+	result, err := execContext(ctx, r.db, "DELETE FROM salas WHERE id = $1", id)
+	if isForeignKeyViolation(err) {
+		return r.inUseError(ctx, id)
+	}
+	return checkAffected(result, err, models.ErrSalaNotFound)
+}
+
+// inUseError explica por que a sala não pôde ser removida. Os alunos removidos não contam na
+// ocupação, mas continuam referenciando a sala até serem expurgados, então também bloqueiam a
+// remoção: nesse caso o erro diz isso, em vez de contradizer a ocupação zerada.
+func (r *salaRepository) inUseError(ctx context.Context, id int) error {
+	var matriculados int
+	err := queryRowContext(ctx, r.db, `SELECT COUNT(*) FROM alunos a JOIN salas s ON s.numero = a.numero_sala
+		WHERE s.id = $1 AND a.deleted_at IS NULL`, id).Scan(&matriculados)
+	if err != nil {
+		return err
+	}
+	if matriculados == 0 {
+		return models.ErrSalaHasRemovedAlunos
+	}
+	return models.ErrSalaInUse
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+// TestUpdateSalaCapacityRace reduz a capacidade da sala enquanto um aluno é matriculado nela:
+// qualquer que seja a ordem, a sala nunca termina com mais alunos do que a capacidade.
+func TestUpdateSalaCapacityRace(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	salas, alunos := NewSalaRepository(db), NewAlunoRepository(db)
+
+	for i := 0; i < 20; i++ {
+		sala := &models.Sala{Capacidade: 2}
+		if err := db.QueryRow("INSERT INTO salas (numero, capacidade) VALUES ((SELECT COALESCE(MAX(numero), 0) + 1 FROM salas), 2) RETURNING id, numero").
+			Scan(&sala.ID, &sala.Numero); err != nil {
+			t.Fatal(err)
+		}
+		if err := alunos.Create(ctx, &models.Aluno{Nome: "Ana Capacidade", Idade: 15, NumeroSala: sala.Numero}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		var createErr, updateErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			createErr = alunos.Create(ctx, &models.Aluno{Nome: "Bia Capacidade", Idade: 15, NumeroSala: sala.Numero})
+		}()
+		go func() {
+			defer wg.Done()
+			updateErr = salas.Update(ctx, &models.Sala{ID: sala.ID, Numero: sala.Numero, Capacidade: 1})
+		}()
+		wg.Wait()
+
+		if createErr != nil && !errors.Is(createErr, models.ErrSalaFull) {
+			t.Fatalf("Create: %v", createErr)
+		}
+		if updateErr != nil && !errors.Is(updateErr, models.ErrSalaCapacity) {
+			t.Fatalf("Update: %v", updateErr)
+		}
+		got, err := salas.GetByID(ctx, sala.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Ocupacao > got.Capacidade {
+			t.Fatalf("sala %d has %d students for capacity %d", got.Numero, got.Ocupacao, got.Capacidade)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/felipemacedo1/dev-cloud-challenge/internal/repository")
+
+// trace cria o span de um método do repositório e mede a sua duração, como observe. Os
+// métodos que recebem o contexto usam trace no lugar de observe:
+//
+//	ctx, end := trace(ctx, "aluno", "List")
+//	defer end()
+func trace(ctx context.Context, repository, method string) (context.Context, func()) {
+	done := observe(repository, method)
+	ctx, span := tracer.Start(ctx, repository+"Repository."+method)
+	return ctx, func() {
+		span.End()
+		done()
+	}
+}
+
+// queryer é a parte comum de *sql.DB e *sql.Tx usada pelas consultas rastreadas.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// As funções abaixo executam a consulta em um span próprio, com o SQL no atributo
+// db.statement. Só o texto da consulta é registrado: os argumentos podem conter dados pessoais.
+// O span de queryContext termina quando a consulta retorna as linhas, antes da leitura delas.
+
+func queryContext(ctx context.Context, q queryer, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startStatement(ctx, query)
+	defer span.End()
+	rows, err := q.QueryContext(ctx, query, args...)
+	recordError(span, err)
+	return rows, err
+}
+
+func queryRowContext(ctx context.Context, q queryer, query string, args ...interface{}) *sql.Row {
+	ctx, span := startStatement(ctx, query)
+	defer span.End()
+	row := q.QueryRowContext(ctx, query, args...)
+	if err := row.Err(); !errors.Is(err, sql.ErrNoRows) {
+		recordError(span, err)
+	}
+	return row
+}
+
+func execContext(ctx context.Context, q queryer, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startStatement(ctx, query)
+	defer span.End()
+	result, err := q.ExecContext(ctx, query, args...)
+	recordError(span, err)
+	return result, err
+}
+
+// startStatement cria o span da consulta, nomeado pela operação (SELECT, UPDATE...).
+func startStatement(ctx context.Context, query string) (context.Context, oteltrace.Span) {
+	words := strings.Fields(query)
+	operation := "SQL"
+	if len(words) > 0 {
+		operation = strings.ToUpper(words[0])
+	}
+	ctx, span := tracer.Start(ctx, operation,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+		),
+	)
+	if span.IsRecording() {
+		span.SetAttributes(attribute.String("db.statement", strings.Join(words, " ")))
+	}
+	return ctx, span
+}
+
+// recordError marca o span como falho. Erros nulos são ignorados.
+func recordError(span oteltrace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
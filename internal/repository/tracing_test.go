@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+var (
+	spanRecorder     = tracetest.NewSpanRecorder()
+	spanRecorderOnce sync.Once
+)
+
+// tracerProvider registra o provider global dos testes uma única vez: o tracer do pacote fica
+// ligado ao primeiro provider registrado.
+func tracerProvider() oteltrace.TracerProvider {
+	spanRecorderOnce.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	})
+	return otel.GetTracerProvider()
+}
+
+func TestTraceSpans(t *testing.T) {
+	ctx, root := tracerProvider().Tracer("test").Start(context.Background(), "alunoService.AtualizarSituacao")
+	ctx, end := trace(ctx, "avaliacao", "ListByAluno")
+	_, statement := startStatement(ctx, "SELECT av.id\n\t\tFROM avaliacoes av WHERE av.aluno_id = $1")
+	recordError(statement, errors.New("conexão perdida"))
+	statement.End()
+	end()
+	root.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range spanRecorder.Ended() {
+		if span.SpanContext().TraceID() == root.SpanContext().TraceID() {
+			spans[span.Name()] = span
+		}
+	}
+	repo, sql := spans["avaliacaoRepository.ListByAluno"], spans["SELECT"]
+	if repo == nil || sql == nil {
+		t.Fatalf("recorded spans = %v, want the repository and statement spans", spans)
+	}
+	if repo.Parent().SpanID() != root.SpanContext().SpanID() || sql.Parent().SpanID() != repo.SpanContext().SpanID() {
+		t.Errorf("spans are not nested: service %s, repository %s (parent %s), statement parent %s",
+			root.SpanContext().SpanID(), repo.SpanContext().SpanID(), repo.Parent().SpanID(), sql.Parent().SpanID())
+	}
+	if sql.SpanKind() != oteltrace.SpanKindClient {
+		t.Errorf("statement span kind = %v, want client", sql.SpanKind())
+	}
+	want := attribute.String("db.statement", "SELECT av.id FROM avaliacoes av WHERE av.aluno_id = $1")
+	found := false
+	for _, a := range sql.Attributes() {
+		found = found || a == want
+	}
+	if !found {
+		t.Errorf("statement attributes = %v, want %v", sql.Attributes(), want)
+	}
+	if sql.Status().Code != codes.Error || repo.Status().Code != codes.Unset {
+		t.Errorf("status = %v (statement), %v (repository), want the error only on the statement", sql.Status().Code, repo.Status().Code)
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+)
+
+// withTx executa fn em uma transação, confirmada se fn não retornar erro e desfeita caso contrário.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logging.FromContext(ctx).WithError(rbErr).Error("Failed to roll back transaction")
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -1,42 +1,494 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+
+	"go.opentelemetry.io/otel"
 )
 
+// tracer cria os spans dos serviços, filhos do span da requisição.
+var tracer = otel.Tracer("github.com/felipemacedo1/dev-cloud-challenge/internal/services")
+
 type AlunoService interface {
-	GetAllAlunos() ([]models.Aluno, error)
-	GetAlunoByID(id int) (*models.Aluno, error)
-	CreateAluno(aluno *models.Aluno) error
-	UpdateAluno(aluno *models.Aluno) error
-	DeleteAluno(id int) error
+	ListAlunos(ctx context.Context, query models.AlunoQuery) (*models.AlunoPage, error)
+	SearchAlunos(ctx context.Context, term string, limit int) ([]models.AlunoSearchResult, error)
+	ListChanges(ctx context.Context, since string, limit int) (*models.AlunoChanges, error)
+	GetAlunoByID(ctx context.Context, id int) (*models.Aluno, error)
+	GetAlunoIncludingDeleted(ctx context.Context, id int) (*models.Aluno, error)
+	GetAlunoAsOf(ctx context.Context, id int, asOf time.Time, includeDeleted bool) (*models.Aluno, error)
+	CreateAluno(ctx context.Context, aluno *models.Aluno) error
+	UpdateAluno(ctx context.Context, aluno *models.Aluno) error
+	PatchAluno(ctx context.Context, id, version int, patch models.AlunoPatch) (*models.Aluno, error)
+	DeleteAluno(ctx context.Context, id, version int) error
+	RestoreAluno(ctx context.Context, id int) (*models.Aluno, error)
+	AtualizarSituacao(ctx context.Context, id int) error
+	RecalcularSituacoes(ctx context.Context) (int, error)
 }
 
 type alunoService struct {
-	repo repository.AlunoRepository
+	repo          repository.AlunoRepository
+	professorRepo repository.ProfessorRepository
+	salaRepo      repository.SalaRepository
+	avaliacaoRepo repository.AvaliacaoRepository
+	criterios     CriteriosAprovacao
+	policy        policy.Policy
+}
+
+// NewAlunoService cria o serviço de alunos. Todas as operações são autorizadas pela policy
+// para o usuário do contexto.
+func NewAlunoService(repo repository.AlunoRepository, professorRepo repository.ProfessorRepository, salaRepo repository.SalaRepository, avaliacaoRepo repository.AvaliacaoRepository, criterios CriteriosAprovacao, policy policy.Policy) AlunoService {
+	return &alunoService{repo, professorRepo, salaRepo, avaliacaoRepo, criterios, policy}
+}
+
+// resolveProfessor associa o aluno a um professor cadastrado. Se professor_id não for informado,
+// o professor é localizado pelo nome_professor, mantendo compatível o cliente que ainda envia
+// apenas o nome. Professores não são cadastrados aqui, e sim em POST /professores.
+func (s *alunoService) resolveProfessor(ctx context.Context, aluno *models.Aluno) error {
+	var professor *models.Professor
+	var err error
+	switch {
+	case aluno.ProfessorID != nil:
+		professor, err = s.professorRepo.GetByID(ctx, *aluno.ProfessorID)
+		if errors.Is(err, models.ErrProfessorNotFound) {
+			return newViolation(models.NewFieldError("professor_id", models.CodeNotFound, "validation.professor_not_found", *aluno.ProfessorID))
+		}
+	case nomeProfessor(aluno.NomeProfessor) != "":
+		nome := nomeProfessor(aluno.NomeProfessor)
+		professor, err = s.professorRepo.FindByNome(ctx, nome)
+		if errors.Is(err, models.ErrProfessorNotFound) {
+			return newViolation(models.NewFieldError("nome_professor", models.CodeNotFound, "validation.professor_nome_not_found", nome))
+		}
+	default:
+		aluno.NomeProfessor = ""
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	aluno.ProfessorID = &professor.ID
+	aluno.NomeProfessor = professor.Nome
+	return nil
+}
+
+// salaError trata o erro da gravação do aluno na sala numero. A vaga é reservada pelo
+// repositório, na mesma transação; se a sala for removida depois de validateAluno verificar a
+// sua existência, a violação é a mesma da validação.
+func salaError(numero int, err error) error {
+	if errors.Is(err, models.ErrSalaNotFound) {
+		return newViolation(*salaNaoCadastrada(numero))
+	}
+	return err
+}
+
+// ListAlunos lista os alunos que o usuário pode consultar; o responsável vê apenas os seus dependentes.
+func (s *alunoService) ListAlunos(ctx context.Context, query models.AlunoQuery) (*models.AlunoPage, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.ListAlunos")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.LerAlunos); err != nil {
+		return nil, err
+	}
+	if query.IncludeDeleted {
+		if err := s.policy.Authorize(ctx, policy.LerAlunosRemovidos); err != nil {
+			return nil, err
+		}
+	}
+	query.IDs = s.policy.AlunosVisiveis(ctx)
+
+	switch query.Situacao {
+	case "", models.SituacaoAprovado, models.SituacaoRecuperacao, models.SituacaoReprovado:
+	default:
+		return nil, models.InvalidQuery("query.unknown_situacao", query.Situacao)
+	}
+	if query.Limit <= 0 {
+		query.Limit = models.DefaultPageLimit
+	}
+	if query.Limit > models.MaxPageLimit {
+		query.Limit = models.MaxPageLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	return s.repo.List(ctx, query)
+}
+
+func (s *alunoService) SearchAlunos(ctx context.Context, term string, limit int) ([]models.AlunoSearchResult, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.SearchAlunos")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.LerAlunos); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(term) == "" {
+		return nil, models.InvalidQuery("query.empty_search")
+	}
+	if limit <= 0 {
+		limit = models.DefaultPageLimit
+	}
+	if limit > models.MaxPageLimit {
+		limit = models.MaxPageLimit
+	}
+	return s.repo.Search(ctx, term, limit, s.policy.AlunosVisiveis(ctx))
+}
+
+// ListChanges retorna as alterações dos alunos que o usuário pode consultar desde o token de
+// sincronização, em páginas de até limit alterações.
+func (s *alunoService) ListChanges(ctx context.Context, since string, limit int) (*models.AlunoChanges, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.ListChanges")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.SincronizarAlunos); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = models.DefaultPageLimit
+	}
+	if limit > models.MaxPageLimit {
+		limit = models.MaxPageLimit
+	}
+	return s.repo.Changes(ctx, since, limit, s.policy.AlunosVisiveis(ctx))
+}
+
+func (s *alunoService) GetAlunoByID(ctx context.Context, id int) (*models.Aluno, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.GetAlunoByID")
+	defer span.End()
+
+	aluno, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.AuthorizeAluno(ctx, policy.LerAlunos, aluno); err != nil {
+		return nil, err
+	}
+	return aluno, nil
+}
+
+// GetAlunoIncludingDeleted retorna o aluno mesmo que ele tenha sido removido (e ainda não expurgado).
+func (s *alunoService) GetAlunoIncludingDeleted(ctx context.Context, id int) (*models.Aluno, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.GetAlunoIncludingDeleted")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.LerAlunosRemovidos); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByIDIncludingDeleted(ctx, id)
+}
+
+// GetAlunoAsOf retorna o aluno como estava no instante asOf. O aluno que já estava removido
+// nesse instante só é retornado com includeDeleted.
+func (s *alunoService) GetAlunoAsOf(ctx context.Context, id int, asOf time.Time, includeDeleted bool) (*models.Aluno, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.GetAlunoAsOf")
+	defer span.End()
+
+	if includeDeleted {
+		if err := s.policy.Authorize(ctx, policy.LerAlunosRemovidos); err != nil {
+			return nil, err
+		}
+	}
+	aluno, err := s.repo.GetByIDAsOf(ctx, id, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if aluno.DeletedAt != nil && !includeDeleted {
+		return nil, models.ErrAlunoNotFound
+	}
+	if err := s.policy.AuthorizeAluno(ctx, policy.LerAlunos, aluno); err != nil {
+		return nil, err
+	}
+	return aluno, nil
+}
+
+func (s *alunoService) CreateAluno(ctx context.Context, aluno *models.Aluno) error {
+	ctx, span := tracer.Start(ctx, "alunoService.CreateAluno")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.MatricularAlunos); err != nil {
+		return err
+	}
+	if err := s.validateAluno(ctx, aluno, true); err != nil {
+		return err
+	}
+	if err := s.resolveProfessor(ctx, aluno); err != nil {
+		return err
+	}
+	aluno.Media, aluno.Situacao = nil, nil
+	return salaError(aluno.NumeroSala, s.repo.Create(ctx, aluno))
+}
+
+// checkVersion verifica a versão esperada antes de validar a alteração; o repositório verifica
+// novamente ao gravar, para o caso de outra alteração acontecer nesse meio tempo.
+func checkVersion(current *models.Aluno, version int) error {
+	if version != models.AnyVersion && current.Version != version {
+		return models.ErrAlunoVersionMismatch
+	}
+	return nil
+}
+
+// UpdateAluno substitui os dados do aluno se ele ainda estiver em aluno.Version (a versão
+// esperada pelo cliente). Ao final, aluno contém a nova versão.
+func (s *alunoService) UpdateAluno(ctx context.Context, aluno *models.Aluno) error {
+	ctx, span := tracer.Start(ctx, "alunoService.UpdateAluno")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.MatricularAlunos); err != nil {
+		return err
+	}
+	// Se o aluno não existir, o repositório responde ErrAlunoNotFound ao gravar, depois da
+	// validação. A existência da sala só é verificada se o aluno trocar de sala.
+	current, err := s.repo.GetByID(ctx, aluno.ID)
+	switch {
+	case errors.Is(err, models.ErrAlunoNotFound):
+		current = nil
+	case err != nil:
+		return err
+	default:
+		if err := checkVersion(current, aluno.Version); err != nil {
+			return err
+		}
+	}
+	novaSala := current == nil || current.NumeroSala != aluno.NumeroSala
+	if err := s.validateAluno(ctx, aluno, novaSala); err != nil {
+		return err
+	}
+	if err := s.resolveProfessor(ctx, aluno); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, aluno); err != nil {
+		return salaError(aluno.NumeroSala, err)
+	}
+
+	// A troca de sala pode trocar a política de avaliação do aluno.
+	if novaSala {
+		updated, err := s.atualizarSituacao(ctx, aluno.ID)
+		if err != nil {
+			return err
+		}
+		aluno.Media, aluno.Situacao = updated.Media, updated.Situacao
+		aluno.Version, aluno.UpdatedAt = updated.Version, updated.UpdatedAt
+		return nil
+	}
+	aluno.Media, aluno.Situacao = current.Media, current.Situacao
+	return nil
+}
+
+// PatchAluno aplica uma atualização parcial, revalidando sala e professor apenas se forem alterados.
+func (s *alunoService) PatchAluno(ctx context.Context, id, version int, patch models.AlunoPatch) (*models.Aluno, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.PatchAluno")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.MatricularAlunos); err != nil {
+		return nil, err
+	}
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(current, version); err != nil {
+		return nil, err
+	}
+
+	salaChanged := patch.NumeroSala != nil && *patch.NumeroSala != current.NumeroSala
+	if err := s.validateAluno(ctx, mergeAlunoPatch(*current, patch), salaChanged); err != nil {
+		return nil, err
+	}
+
+	if patch.ProfessorID != nil || patch.NomeProfessor != nil {
+		ref := models.Aluno{ProfessorID: patch.ProfessorID}
+		if patch.NomeProfessor != nil {
+			ref.NomeProfessor = *patch.NomeProfessor
+		}
+		if err := s.resolveProfessor(ctx, &ref); err != nil {
+			return nil, err
+		}
+		patch.ProfessorID = ref.ProfessorID
+		patch.ClearProfessor = ref.ProfessorID == nil
+	}
+
+	if err := s.repo.UpdatePartial(ctx, id, version, patch); err != nil {
+		if patch.NumeroSala != nil {
+			return nil, salaError(*patch.NumeroSala, err)
+		}
+		return nil, err
+	}
+
+	// A troca de sala pode trocar a política de avaliação do aluno.
+	if salaChanged {
+		return s.atualizarSituacao(ctx, id)
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// mergeAlunoPatch retorna o aluno com os campos do patch aplicados, para validação.
+func mergeAlunoPatch(aluno models.Aluno, patch models.AlunoPatch) *models.Aluno {
+	if patch.Nome != nil {
+		aluno.Nome = *patch.Nome
+	}
+	if patch.Idade != nil {
+		aluno.Idade = *patch.Idade
+	}
+	if patch.NumeroSala != nil {
+		aluno.NumeroSala = *patch.NumeroSala
+	}
+	if patch.ProfessorID != nil || patch.NomeProfessor != nil || patch.ClearProfessor {
+		aluno.ProfessorID, aluno.NomeProfessor = patch.ProfessorID, ""
+		if patch.NomeProfessor != nil {
+			aluno.NomeProfessor = *patch.NomeProfessor
+		}
+	}
+	return &aluno
 }
 
-func NewAlunoService(repo repository.AlunoRepository) AlunoService {
-	return &alunoService{repo}
+// DeleteAluno marca o aluno como removido; ele pode ser restaurado até ser expurgado.
+func (s *alunoService) DeleteAluno(ctx context.Context, id, version int) error {
+	ctx, span := tracer.Start(ctx, "alunoService.DeleteAluno")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.MatricularAlunos); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id, version)
 }
 
-func (s *alunoService) GetAllAlunos() ([]models.Aluno, error) {
-	return s.repo.GetAll()
+// RestoreAluno desfaz a remoção do aluno, se ainda houver vaga na sala dele. Restaurar um aluno
+// que não foi removido não altera nada.
+func (s *alunoService) RestoreAluno(ctx context.Context, id int) (*models.Aluno, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.RestoreAluno")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.MatricularAlunos); err != nil {
+		return nil, err
+	}
+	aluno, err := s.repo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if aluno.DeletedAt == nil {
+		return aluno, nil
+	}
+	return aluno, s.repo.Restore(ctx, aluno)
 }
 
-func (s *alunoService) GetAlunoByID(id int) (*models.Aluno, error) {
-	return s.repo.GetByID(id)
+// calcularDesempenho retorna a média do aluno (a média das médias de cada disciplina, calculadas
+// pela política de avaliação da sala e arredondada conforme os critérios) e a situação
+// correspondente, ou nil se o aluno ainda não tiver notas.
+func (s *alunoService) calcularDesempenho(aluno *models.Aluno, notas []models.Avaliacao) (*float64, *string) {
+	if len(notas) == 0 {
+		return nil, nil
+	}
+
+	policy := s.criterios.Politicas.Para(aluno.NumeroSala)
+	porDisciplina := map[int][]models.Avaliacao{}
+	for _, n := range notas {
+		porDisciplina[n.DisciplinaID] = append(porDisciplina[n.DisciplinaID], n)
+	}
+
+	var soma float64
+	for _, notasDisciplina := range porDisciplina {
+		soma += policy.Media(notasDisciplina)
+	}
+
+	media := s.criterios.Arredondar(soma / float64(len(porDisciplina)))
+	situacao := s.criterios.Situacao(media)
+	return &media, &situacao
 }
 
-func (s *alunoService) CreateAluno(aluno *models.Aluno) error {
-	return s.repo.Create(aluno)
+// AtualizarSituacao recalcula e grava a média e a situação de um aluno a partir das suas notas.
+// É chamada após as alterações de notas, e exige a mesma permissão.
+func (s *alunoService) AtualizarSituacao(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "alunoService.AtualizarSituacao")
+	defer span.End()
+
+	aluno, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.AuthorizeAluno(ctx, policy.LancarNotas, aluno); err != nil {
+		return err
+	}
+	return s.gravarSituacao(ctx, aluno)
 }
 
-func (s *alunoService) UpdateAluno(aluno *models.Aluno) error {
-	return s.repo.Update(aluno)
+func (s *alunoService) atualizarSituacao(ctx context.Context, id int) (*models.Aluno, error) {
+	aluno, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return aluno, s.gravarSituacao(ctx, aluno)
+}
+
+func (s *alunoService) gravarSituacao(ctx context.Context, aluno *models.Aluno) error {
+	notas, err := s.avaliacaoRepo.ListByAluno(ctx, aluno.ID)
+	if err != nil {
+		return err
+	}
+	aluno.Media, aluno.Situacao = s.calcularDesempenho(aluno, notas)
+	// As notas acabaram de ser lidas: o resultado vale mesmo que o aluno tenha mudado desde então.
+	aluno.Version = models.AnyVersion
+	return s.repo.UpdateSituacao(ctx, aluno)
+}
+
+// RecalcularSituacoes percorre todos os alunos recalculando média e situação, para que mudanças
+// nos critérios de aprovação passem a valer para quem já tinha notas. Retorna quantos mudaram.
+// Pode rodar junto com as requisições: o aluno alterado depois de listado (uma nota nova, por
+// exemplo) já teve a situação recalculada com os critérios atuais e não é sobrescrito.
+func (s *alunoService) RecalcularSituacoes(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "alunoService.RecalcularSituacoes")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, policy.RecalcularSituacoes); err != nil {
+		return 0, err
+	}
+	query := models.AlunoQuery{Limit: models.MaxPageLimit}
+	atualizados := 0
+	for {
+		page, err := s.repo.List(ctx, query)
+		if err != nil {
+			return atualizados, err
+		}
+
+		ids := make([]int, len(page.Data))
+		for i, aluno := range page.Data {
+			ids[i] = aluno.ID
+		}
+		notas, err := s.avaliacaoRepo.ListByAlunos(ctx, ids)
+		if err != nil {
+			return atualizados, err
+		}
+
+		for _, aluno := range page.Data {
+			media, situacao := s.calcularDesempenho(&aluno, notas[aluno.ID])
+			if equalPtr(aluno.Media, media) && equalPtr(aluno.Situacao, situacao) {
+				continue
+			}
+			// O aluno pode ter sido removido ou alterado depois de listado.
+			aluno.Media, aluno.Situacao = media, situacao
+			err := s.repo.UpdateSituacao(ctx, &aluno)
+			if errors.Is(err, models.ErrAlunoNotFound) || errors.Is(err, models.ErrAlunoVersionMismatch) {
+				continue
+			}
+			if err != nil {
+				return atualizados, err
+			}
+			atualizados++
+		}
+
+		if page.Meta.NextCursor == "" {
+			return atualizados, nil
+		}
+		query.Cursor = page.Meta.NextCursor
+	}
 }
 
-func (s *alunoService) DeleteAluno(id int) error {
-	return s.repo.Delete(id)
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+// alunoRepoStub responde GetByID com aluno ou err e registra as gravações; os demais métodos
+// não são usados nos testes.
+type alunoRepoStub struct {
+	repository.AlunoRepository
+	aluno   *models.Aluno
+	err     error
+	updated bool
+}
+
+func (r *alunoRepoStub) GetByID(ctx context.Context, id int) (*models.Aluno, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.aluno == nil {
+		return nil, models.ErrAlunoNotFound
+	}
+	aluno := *r.aluno
+	return &aluno, nil
+}
+
+func (r *alunoRepoStub) Update(ctx context.Context, aluno *models.Aluno) error {
+	r.updated = true
+	if r.aluno == nil {
+		return models.ErrAlunoNotFound
+	}
+	return nil
+}
+
+func TestUpdateAlunoGetByIDError(t *testing.T) {
+	errBanco := errors.New("conexão perdida")
+	tests := []struct {
+		name        string
+		repo        *alunoRepoStub
+		wantErr     error
+		wantUpdated bool
+	}{
+		{name: "falha na leitura", repo: &alunoRepoStub{err: errBanco}, wantErr: errBanco},
+		{name: "aluno inexistente", repo: &alunoRepoStub{}, wantErr: models.ErrAlunoNotFound, wantUpdated: true},
+		{
+			name:    "versão desatualizada",
+			repo:    &alunoRepoStub{aluno: &models.Aluno{ID: 1, Nome: "Ana", Idade: 15, NumeroSala: 101, Version: 3}},
+			wantErr: models.ErrAlunoVersionMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &alunoService{repo: tt.repo, salaRepo: salaRepoStub{salas: map[int]bool{101: true}}, policy: policy.NewRBAC()}
+			aluno := &models.Aluno{ID: 1, Nome: "Ana", Idade: 15, NumeroSala: 101, Version: 2}
+			err := s.UpdateAluno(policy.SystemContext(context.Background()), aluno)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateAluno error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.repo.updated != tt.wantUpdated {
+				t.Errorf("Update called = %v, want %v", tt.repo.updated, tt.wantUpdated)
+			}
+		})
+	}
+}
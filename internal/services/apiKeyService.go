@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/actor"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/auth"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+// Formato das chaves de API: o prefixo fixo seguido de 32 bytes aleatórios em base64url. Os
+// primeiros apiKeyPrefixoLength caracteres identificam a chave nas listagens.
+const (
+	apiKeyPrefix        = "dcc_"
+	apiKeyRandomBytes   = 32
+	apiKeyPrefixoLength = 12
+)
+
+var apiKeyScopes = []string{models.ScopeAlunosRead, models.ScopeAlunosWrite}
+
+type APIKeyService interface {
+	ListAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	CreateAPIKey(ctx context.Context, nova models.NovaAPIKey) (*models.APIKeyCriada, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*auth.Principal, error)
+}
+
+type apiKeyService struct {
+	repo   repository.APIKeyRepository
+	policy policy.Policy
+	now    func() time.Time
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepository, policy policy.Policy) APIKeyService {
+	return &apiKeyService{repo, policy, time.Now}
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	if err := s.policy.Authorize(ctx, policy.GerenciarAPIKeys); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx)
+}
+
+// CreateAPIKey gera uma nova chave. O valor da chave só é conhecido nesta resposta: o banco
+// guarda apenas o hash.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, nova models.NovaAPIKey) (*models.APIKeyCriada, error) {
+	if err := s.policy.Authorize(ctx, policy.GerenciarAPIKeys); err != nil {
+		return nil, err
+	}
+	nova.Nome = strings.TrimSpace(nova.Nome)
+	checks := []fieldCheck{
+		field("nome", nova.Nome, required(), maxLength(models.MaxNomeLength)),
+		field("scopes", nova.Scopes, scopesConhecidos()),
+	}
+	if nova.ExpiresAt != nil {
+		checks = append(checks, field("expires_at", *nova.ExpiresAt, s.futura()))
+	}
+	if err := validate(checks...); err != nil {
+		return nil, err
+	}
+
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+	scopes := slices.Clone(nova.Scopes)
+	slices.Sort(scopes)
+
+	created := &models.APIKeyCriada{
+		APIKey: models.APIKey{
+			Nome:      nova.Nome,
+			Prefixo:   key[:apiKeyPrefixoLength],
+			Scopes:    slices.Compact(scopes),
+			CriadaPor: actor.FromContext(ctx),
+			ExpiresAt: nova.ExpiresAt,
+		},
+		Key: key,
+	}
+	if err := s.repo.Create(ctx, &created.APIKey, hashAPIKey(key)); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id int) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarAPIKeys); err != nil {
+		return err
+	}
+	return s.repo.Revoke(ctx, id)
+}
+
+// AuthenticateAPIKey identifica a chave de API recebida em X-API-Key e registra o seu uso. O
+// usuário autenticado tem os escopos da chave e é identificado na auditoria como api-key:<id>.
+func (s *apiKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*auth.Principal, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	apiKey, err := s.repo.Use(ctx, hashAPIKey(key))
+	if errors.Is(err, models.ErrAPIKeyNotFound) {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Principal{
+		Subject: "api-key:" + strconv.Itoa(apiKey.ID),
+		Name:    apiKey.Nome,
+		Scopes:  apiKey.Scopes,
+	}, nil
+}
+
+// hashAPIKey calcula o hash armazenado da chave. As chaves são aleatórias e longas, então um
+// hash rápido basta: não há como adivinhá-las por força bruta.
+func hashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+func scopesConhecidos() rule[[]string] {
+	return func(scopes []string) (*models.FieldError, error) {
+		if len(scopes) == 0 {
+			return fieldError(models.CodeRequired, "validation.required"), nil
+		}
+		for _, scope := range scopes {
+			if !slices.Contains(apiKeyScopes, scope) {
+				return fieldError(models.CodeInvalid, "validation.unknown_scope", scope, strings.Join(apiKeyScopes, ", ")), nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func (s *apiKeyService) futura() rule[time.Time] {
+	return func(value time.Time) (*models.FieldError, error) {
+		if !value.After(s.now()) {
+			return fieldError(models.CodeOutOfRange, "validation.future_date"), nil
+		}
+		return nil, nil
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+type AuditoriaService interface {
+	ListAuditoria(ctx context.Context, query models.AuditoriaQuery) (*models.AuditoriaPage, error)
+	HistoricoAluno(ctx context.Context, alunoID, limit, offset int) (*models.AuditoriaPage, error)
+}
+
+type auditoriaService struct {
+	repo      repository.AuditoriaRepository
+	alunoRepo repository.AlunoRepository
+	policy    policy.Policy
+}
+
+func NewAuditoriaService(repo repository.AuditoriaRepository, alunoRepo repository.AlunoRepository, policy policy.Policy) AuditoriaService {
+	return &auditoriaService{repo, alunoRepo, policy}
+}
+
+func (s *auditoriaService) ListAuditoria(ctx context.Context, query models.AuditoriaQuery) (*models.AuditoriaPage, error) {
+	if err := s.policy.Authorize(ctx, policy.LerAuditoria); err != nil {
+		return nil, err
+	}
+	return s.list(ctx, query)
+}
+
+func (s *auditoriaService) list(ctx context.Context, query models.AuditoriaQuery) (*models.AuditoriaPage, error) {
+	switch query.Operacao {
+	case "", models.OperacaoCriacao, models.OperacaoAlteracao, models.OperacaoSituacao,
+		models.OperacaoRemocao, models.OperacaoRestauracao, models.OperacaoExpurgo:
+	default:
+		return nil, models.InvalidQuery("query.unknown_operacao", query.Operacao)
+	}
+	if query.Limit <= 0 {
+		query.Limit = models.DefaultPageLimit
+	}
+	if query.Limit > models.MaxPageLimit {
+		query.Limit = models.MaxPageLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	return s.repo.List(ctx, query)
+}
+
+// HistoricoAluno retorna as alterações de um aluno, da mais recente para a mais antiga. O
+// histórico continua disponível depois que o aluno é removido ou expurgado; só é
+// ErrAlunoNotFound o aluno que nunca existiu.
+func (s *auditoriaService) HistoricoAluno(ctx context.Context, alunoID, limit, offset int) (*models.AuditoriaPage, error) {
+	if err := s.policy.Authorize(ctx, policy.LerHistorico); err != nil {
+		return nil, err
+	}
+	page, err := s.list(ctx, models.AuditoriaQuery{AlunoID: &alunoID, Limit: limit, Offset: offset})
+	if err != nil || page.Meta.Total > 0 {
+		return page, err
+	}
+	if _, err := s.alunoRepo.GetByIDIncludingDeleted(ctx, alunoID); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+type AvaliacaoService interface {
+	ListNotas(ctx context.Context, alunoID int) ([]models.Avaliacao, error)
+	CreateNota(ctx context.Context, avaliacao *models.Avaliacao) error
+	UpdateNota(ctx context.Context, avaliacao *models.Avaliacao) error
+	DeleteNota(ctx context.Context, alunoID, id int) error
+}
+
+type avaliacaoService struct {
+	repo         repository.AvaliacaoRepository
+	alunoService AlunoService
+	policy       policy.Policy
+}
+
+// NewAvaliacaoService cria o serviço de notas. Cada alteração de nota pede ao alunoService que
+// recalcule a média e a situação do aluno. As notas são consultadas por quem pode consultar o
+// aluno e alteradas por quem a policy permite lançar notas dele.
+func NewAvaliacaoService(repo repository.AvaliacaoRepository, alunoService AlunoService, policy policy.Policy) AvaliacaoService {
+	return &avaliacaoService{repo, alunoService, policy}
+}
+
+// autorizarNotas verifica se o aluno existe e se o usuário pode lançar as notas dele.
+func (s *avaliacaoService) autorizarNotas(ctx context.Context, alunoID int) error {
+	aluno, err := s.alunoService.GetAlunoByID(ctx, alunoID)
+	if err != nil {
+		return err
+	}
+	return s.policy.AuthorizeAluno(ctx, policy.LancarNotas, aluno)
+}
+
+func (s *avaliacaoService) ListNotas(ctx context.Context, alunoID int) ([]models.Avaliacao, error) {
+	if _, err := s.alunoService.GetAlunoByID(ctx, alunoID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByAluno(ctx, alunoID)
+}
+
+func (s *avaliacaoService) CreateNota(ctx context.Context, avaliacao *models.Avaliacao) error {
+	if err := s.autorizarNotas(ctx, avaliacao.AlunoID); err != nil {
+		return err
+	}
+	avaliacao.Descricao = strings.TrimSpace(avaliacao.Descricao)
+	if err := validateAvaliacao(avaliacao); err != nil {
+		return err
+	}
+	if err := s.repo.Create(ctx, avaliacao); err != nil {
+		return disciplinaReferenceError(avaliacao, err)
+	}
+	return s.alunoService.AtualizarSituacao(ctx, avaliacao.AlunoID)
+}
+
+func (s *avaliacaoService) UpdateNota(ctx context.Context, avaliacao *models.Avaliacao) error {
+	if err := s.autorizarNotas(ctx, avaliacao.AlunoID); err != nil {
+		return err
+	}
+	avaliacao.Descricao = strings.TrimSpace(avaliacao.Descricao)
+	if err := validateAvaliacao(avaliacao); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, avaliacao); err != nil {
+		return disciplinaReferenceError(avaliacao, err)
+	}
+	return s.alunoService.AtualizarSituacao(ctx, avaliacao.AlunoID)
+}
+
+func (s *avaliacaoService) DeleteNota(ctx context.Context, alunoID, id int) error {
+	if err := s.autorizarNotas(ctx, alunoID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, alunoID, id); err != nil {
+		return err
+	}
+	return s.alunoService.AtualizarSituacao(ctx, alunoID)
+}
+
+// disciplinaReferenceError reporta a disciplina inexistente como violação do campo disciplina_id.
+func disciplinaReferenceError(avaliacao *models.Avaliacao, err error) error {
+	if errors.Is(err, models.ErrDisciplinaNotFound) {
+		return newViolation(models.NewFieldError("disciplina_id", models.CodeNotFound, "validation.disciplina_not_found", avaliacao.DisciplinaID))
+	}
+	return err
+}
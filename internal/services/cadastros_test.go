@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/auth"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+// Os stubs dos repositórios de cadastros contam as gravações; os demais métodos não são usados.
+type professorRepoStub struct {
+	repository.ProfessorRepository
+	writes int
+}
+
+func (r *professorRepoStub) Create(context.Context, *models.Professor) error { r.writes++; return nil }
+func (r *professorRepoStub) Update(context.Context, *models.Professor) error { r.writes++; return nil }
+func (r *professorRepoStub) Delete(context.Context, int) error               { r.writes++; return nil }
+
+type salaWriteRepoStub struct {
+	repository.SalaRepository
+	writes int
+}
+
+func (r *salaWriteRepoStub) Create(context.Context, *models.Sala) error { r.writes++; return nil }
+func (r *salaWriteRepoStub) Update(context.Context, *models.Sala) error { r.writes++; return nil }
+func (r *salaWriteRepoStub) Delete(context.Context, int) error          { r.writes++; return nil }
+
+type disciplinaRepoStub struct {
+	repository.DisciplinaRepository
+	writes int
+}
+
+func (r *disciplinaRepoStub) Create(context.Context, *models.Disciplina) error {
+	r.writes++
+	return nil
+}
+func (r *disciplinaRepoStub) Update(context.Context, *models.Disciplina) error {
+	r.writes++
+	return nil
+}
+func (r *disciplinaRepoStub) Delete(context.Context, int) error { r.writes++; return nil }
+
+// TestCadastrosAuthorize verifica que os serviços de cadastros autorizam as alterações por conta
+// própria, sem depender das rotas.
+func TestCadastrosAuthorize(t *testing.T) {
+	usuarios := []struct {
+		name      string
+		ctx       context.Context
+		permitido bool
+	}{
+		{name: "sem usuário", ctx: context.Background()},
+		{name: "professor", ctx: auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "p", Roles: []string{policy.RoleProfessor}})},
+		{name: "responsável", ctx: auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "r", Roles: []string{policy.RoleResponsavel}})},
+		{name: "chave de API de escrita", ctx: auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "k", Scopes: []string{models.ScopeAlunosWrite}})},
+		{name: "secretaria", ctx: auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "s", Roles: []string{policy.RoleSecretaria}}), permitido: true},
+		{name: "admin", ctx: policy.SystemContext(context.Background()), permitido: true},
+	}
+	for _, u := range usuarios {
+		t.Run(u.name, func(t *testing.T) {
+			professores := &professorRepoStub{}
+			salas := &salaWriteRepoStub{}
+			disciplinas := &disciplinaRepoStub{}
+			professorService := NewProfessorService(professores, policy.NewRBAC())
+			salaService := NewSalaService(salas, policy.NewRBAC())
+			disciplinaService := NewDisciplinaService(disciplinas, policy.NewRBAC())
+
+			operacoes := map[string]error{
+				"CreateProfessor":  professorService.CreateProfessor(u.ctx, &models.Professor{Nome: "Silva"}),
+				"UpdateProfessor":  professorService.UpdateProfessor(u.ctx, &models.Professor{ID: 1, Nome: "Silva"}),
+				"DeleteProfessor":  professorService.DeleteProfessor(u.ctx, 1),
+				"CreateSala":       salaService.CreateSala(u.ctx, &models.Sala{Numero: 101, Capacidade: 40}),
+				"UpdateSala":       salaService.UpdateSala(u.ctx, &models.Sala{ID: 1, Numero: 101, Capacidade: 40}),
+				"DeleteSala":       salaService.DeleteSala(u.ctx, 1),
+				"CreateDisciplina": disciplinaService.CreateDisciplina(u.ctx, &models.Disciplina{Nome: "Matemática"}),
+				"UpdateDisciplina": disciplinaService.UpdateDisciplina(u.ctx, &models.Disciplina{ID: 1, Nome: "Matemática"}),
+				"DeleteDisciplina": disciplinaService.DeleteDisciplina(u.ctx, 1),
+			}
+			for operacao, err := range operacoes {
+				if u.permitido && err != nil {
+					t.Errorf("%s: %v", operacao, err)
+				}
+				if !u.permitido && !errors.Is(err, models.ErrAccessDenied) {
+					t.Errorf("%s error = %v, want ErrAccessDenied", operacao, err)
+				}
+			}
+
+			wantWrites := 0
+			if u.permitido {
+				wantWrites = 3
+			}
+			if professores.writes != wantWrites || salas.writes != wantWrites || disciplinas.writes != wantWrites {
+				t.Errorf("writes = %d professores, %d salas, %d disciplinas, want %d each",
+					professores.writes, salas.writes, disciplinas.writes, wantWrites)
+			}
+		})
+	}
+}
+
+// TestCadastrosValidation verifica que os nomes dos cadastros são validados depois de
+// normalizados, antes de chegarem ao banco.
+func TestCadastrosValidation(t *testing.T) {
+	longo := strings.Repeat("á", models.MaxNomeLength+1)
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, professores ProfessorService, salas SalaService, disciplinas DisciplinaService) error
+		want []violation
+	}{
+		{
+			name: "professor só com o título",
+			run: func(ctx context.Context, professores ProfessorService, _ SalaService, _ DisciplinaService) error {
+				return professores.CreateProfessor(ctx, &models.Professor{Nome: "Prof. "})
+			},
+			want: []violation{{"nome", models.CodeRequired, "campo obrigatório", "required field"}},
+		},
+		{
+			name: "professor com nome longo",
+			run: func(ctx context.Context, professores ProfessorService, _ SalaService, _ DisciplinaService) error {
+				return professores.UpdateProfessor(ctx, &models.Professor{ID: 1, Nome: longo})
+			},
+			want: []violation{{"nome", models.CodeMaxLength, "deve ter no máximo 100 caracteres", "must be at most 100 characters long"}},
+		},
+		{
+			name: "professor no limite sem o título",
+			run: func(ctx context.Context, professores ProfessorService, _ SalaService, _ DisciplinaService) error {
+				return professores.CreateProfessor(ctx, &models.Professor{Nome: "Profa. " + strings.Repeat("á", models.MaxNomeLength)})
+			},
+		},
+		{
+			name: "prédio longo",
+			run: func(ctx context.Context, _ ProfessorService, salas SalaService, _ DisciplinaService) error {
+				return salas.CreateSala(ctx, &models.Sala{Numero: 101, Predio: longo, Capacidade: 40})
+			},
+			want: []violation{{"predio", models.CodeMaxLength, "deve ter no máximo 100 caracteres", "must be at most 100 characters long"}},
+		},
+		{
+			name: "sala sem prédio",
+			run: func(ctx context.Context, _ ProfessorService, salas SalaService, _ DisciplinaService) error {
+				return salas.UpdateSala(ctx, &models.Sala{ID: 1, Numero: 101, Predio: "  ", Capacidade: 40})
+			},
+		},
+		{
+			name: "disciplina em branco",
+			run: func(ctx context.Context, _ ProfessorService, _ SalaService, disciplinas DisciplinaService) error {
+				return disciplinas.CreateDisciplina(ctx, &models.Disciplina{Nome: "  "})
+			},
+			want: []violation{{"nome", models.CodeRequired, "campo obrigatório", "required field"}},
+		},
+		{
+			name: "disciplina com nome longo",
+			run: func(ctx context.Context, _ ProfessorService, _ SalaService, disciplinas DisciplinaService) error {
+				return disciplinas.UpdateDisciplina(ctx, &models.Disciplina{ID: 1, Nome: longo})
+			},
+			want: []violation{{"nome", models.CodeMaxLength, "deve ter no máximo 100 caracteres", "must be at most 100 characters long"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			professores, salas, disciplinas := &professorRepoStub{}, &salaWriteRepoStub{}, &disciplinaRepoStub{}
+			err := tt.run(policy.SystemContext(context.Background()),
+				NewProfessorService(professores, policy.NewRBAC()), NewSalaService(salas, policy.NewRBAC()),
+				NewDisciplinaService(disciplinas, policy.NewRBAC()))
+
+			if got := violations(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %+v, want %+v", got, tt.want)
+			}
+			wantWrites := 0
+			if tt.want == nil {
+				wantWrites = 1
+			}
+			if writes := professores.writes + salas.writes + disciplinas.writes; writes != wantWrites {
+				t.Errorf("repository writes = %d, want %d", writes, wantWrites)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+// Arredondamento define como a média é arredondada antes de ser comparada com os limites.
+type Arredondamento string
+
+const (
+	ArredondamentoNenhum    Arredondamento = "nenhum"
+	ArredondamentoDecimal   Arredondamento = "decimal"    // meio para cima, em CasasDecimais casas
+	ArredondamentoMeioPonto Arredondamento = "meio_ponto" // para o 0,5 mais próximo (6,74 -> 6,5; 6,75 -> 7,0)
+)
+
+// CriteriosAprovacao são as regras de cálculo da média e da situação do aluno, configuráveis por implantação.
+type CriteriosAprovacao struct {
+	MediaAprovacao   float64
+	MediaRecuperacao float64
+	Arredondamento   Arredondamento
+	CasasDecimais    int
+	Politicas        PoliticasAvaliacao
+}
+
+func DefaultCriteriosAprovacao() CriteriosAprovacao {
+	return CriteriosAprovacao{
+		MediaAprovacao:   7,
+		MediaRecuperacao: 5,
+		Arredondamento:   ArredondamentoDecimal,
+		CasasDecimais:    1,
+		Politicas:        PoliticasAvaliacao{Padrao: MediaSimples{}},
+	}
+}
+
+// LoadCriteriosAprovacao lê os critérios das variáveis MEDIA_APROVACAO, MEDIA_RECUPERACAO,
+// ARREDONDAMENTO_MEDIA e CASAS_DECIMAIS_MEDIA, usando os valores padrão para as ausentes,
+// e as políticas de média (ver LoadPoliticasAvaliacao).
+func LoadCriteriosAprovacao() (CriteriosAprovacao, error) {
+	c := DefaultCriteriosAprovacao()
+
+	politicas, err := LoadPoliticasAvaliacao()
+	if err != nil {
+		return c, err
+	}
+	c.Politicas = politicas
+
+	if v := os.Getenv("MEDIA_APROVACAO"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return c, fmt.Errorf("MEDIA_APROVACAO inválida: %w", err)
+		}
+		c.MediaAprovacao = f
+	}
+	if v := os.Getenv("MEDIA_RECUPERACAO"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return c, fmt.Errorf("MEDIA_RECUPERACAO inválida: %w", err)
+		}
+		c.MediaRecuperacao = f
+	}
+	if v := os.Getenv("ARREDONDAMENTO_MEDIA"); v != "" {
+		c.Arredondamento = Arredondamento(v)
+	}
+	if v := os.Getenv("CASAS_DECIMAIS_MEDIA"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return c, fmt.Errorf("CASAS_DECIMAIS_MEDIA inválida: %q", v)
+		}
+		c.CasasDecimais = n
+	}
+
+	switch c.Arredondamento {
+	case ArredondamentoNenhum, ArredondamentoDecimal, ArredondamentoMeioPonto:
+	default:
+		return c, fmt.Errorf("ARREDONDAMENTO_MEDIA inválido: %q", c.Arredondamento)
+	}
+	// A comparação negada também recusa NaN
+	if !(c.MediaAprovacao >= models.NotaMinima && c.MediaAprovacao <= models.NotaMaxima) {
+		return c, fmt.Errorf("MEDIA_APROVACAO fora do intervalo das notas (%v a %v): %v", models.NotaMinima, models.NotaMaxima, c.MediaAprovacao)
+	}
+	if !(c.MediaRecuperacao >= models.NotaMinima && c.MediaRecuperacao <= models.NotaMaxima) {
+		return c, fmt.Errorf("MEDIA_RECUPERACAO fora do intervalo das notas (%v a %v): %v", models.NotaMinima, models.NotaMaxima, c.MediaRecuperacao)
+	}
+	if c.MediaRecuperacao > c.MediaAprovacao {
+		return c, fmt.Errorf("MEDIA_RECUPERACAO (%v) maior que MEDIA_APROVACAO (%v)", c.MediaRecuperacao, c.MediaAprovacao)
+	}
+	return c, nil
+}
+
+// arredondamentoEpsilon compensa erros de representação binária (6,95*10 = 69,4999...).
+const arredondamentoEpsilon = 1e-9
+
+func (c CriteriosAprovacao) Arredondar(media float64) float64 {
+	switch c.Arredondamento {
+	case ArredondamentoDecimal:
+		f := math.Pow(10, float64(c.CasasDecimais))
+		return math.Floor(media*f+0.5+arredondamentoEpsilon) / f
+	case ArredondamentoMeioPonto:
+		return math.Floor(media*2+0.5+arredondamentoEpsilon) / 2
+	default:
+		return media
+	}
+}
+
+// Situacao classifica uma média já arredondada.
+func (c CriteriosAprovacao) Situacao(media float64) string {
+	switch {
+	case media >= c.MediaAprovacao:
+		return models.SituacaoAprovado
+	case media >= c.MediaRecuperacao:
+		return models.SituacaoRecuperacao
+	default:
+		return models.SituacaoReprovado
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+func TestArredondar(t *testing.T) {
+	tests := []struct {
+		name           string
+		arredondamento Arredondamento
+		casas          int
+		media          float64
+		want           float64
+	}{
+		{name: "decimal com erro de representação", arredondamento: ArredondamentoDecimal, casas: 1, media: 6.95, want: 7},
+		{name: "decimal logo abaixo do meio", arredondamento: ArredondamentoDecimal, casas: 1, media: 6.9499, want: 6.9},
+		{name: "decimal abaixo do meio por menos que o epsilon", arredondamento: ArredondamentoDecimal, casas: 1, media: 6.95 - 1e-12, want: 7},
+		{name: "decimal para baixo", arredondamento: ArredondamentoDecimal, casas: 1, media: 6.94, want: 6.9},
+		{name: "decimal com duas casas", arredondamento: ArredondamentoDecimal, casas: 2, media: 6.945, want: 6.95},
+		{name: "decimal sem casas", arredondamento: ArredondamentoDecimal, casas: 0, media: 6.5, want: 7},
+		{name: "meio ponto para baixo", arredondamento: ArredondamentoMeioPonto, media: 6.74, want: 6.5},
+		{name: "meio ponto para cima", arredondamento: ArredondamentoMeioPonto, media: 6.75, want: 7},
+		{name: "meio ponto logo abaixo do meio", arredondamento: ArredondamentoMeioPonto, media: 6.7499, want: 6.5},
+		{name: "sem arredondamento", arredondamento: ArredondamentoNenhum, media: 6.9499, want: 6.9499},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := CriteriosAprovacao{Arredondamento: tt.arredondamento, CasasDecimais: tt.casas}
+			if got := c.Arredondar(tt.media); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Arredondar(%v) = %v, want %v", tt.media, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSituacao(t *testing.T) {
+	c := DefaultCriteriosAprovacao()
+	tests := []struct {
+		name  string
+		media float64 // antes do arredondamento
+		want  string
+	}{
+		{name: "acima da aprovação", media: 8.2, want: models.SituacaoAprovado},
+		{name: "na média de aprovação", media: 7, want: models.SituacaoAprovado},
+		{name: "arredondada até a aprovação", media: 6.95, want: models.SituacaoAprovado},
+		{name: "logo abaixo da aprovação", media: 6.9499, want: models.SituacaoRecuperacao},
+		{name: "na média de recuperação", media: 5, want: models.SituacaoRecuperacao},
+		{name: "arredondada até a recuperação", media: 4.95, want: models.SituacaoRecuperacao},
+		{name: "logo abaixo da recuperação", media: 4.9499, want: models.SituacaoReprovado},
+		{name: "zero", media: 0, want: models.SituacaoReprovado},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Situacao(c.Arredondar(tt.media)); got != tt.want {
+				t.Errorf("Situacao(Arredondar(%v)) = %s, want %s", tt.media, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCriteriosAprovacao(t *testing.T) {
+	tests := []struct {
+		name           string
+		aprovacao      string
+		recuperacao    string
+		arredondamento string
+		casas          string
+		want           CriteriosAprovacao
+		wantErr        bool
+	}{
+		{name: "padrão", want: DefaultCriteriosAprovacao()},
+		{name: "configurados", aprovacao: "6", recuperacao: "4.5", arredondamento: "meio_ponto", casas: "2",
+			want: CriteriosAprovacao{MediaAprovacao: 6, MediaRecuperacao: 4.5, Arredondamento: ArredondamentoMeioPonto, CasasDecimais: 2}},
+		{name: "médias iguais", aprovacao: "6", recuperacao: "6",
+			want: CriteriosAprovacao{MediaAprovacao: 6, MediaRecuperacao: 6, Arredondamento: ArredondamentoDecimal, CasasDecimais: 1}},
+		{name: "recuperação acima da aprovação", aprovacao: "6", recuperacao: "6.5", wantErr: true},
+		{name: "recuperação padrão acima da aprovação", aprovacao: "4", wantErr: true},
+		{name: "aprovação acima da nota máxima", aprovacao: "11", wantErr: true},
+		{name: "recuperação negativa", recuperacao: "-1", wantErr: true},
+		{name: "aprovação NaN", aprovacao: "NaN", wantErr: true},
+		{name: "aprovação infinita", aprovacao: "Inf", wantErr: true},
+		{name: "aprovação não numérica", aprovacao: "sete", wantErr: true},
+		{name: "recuperação não numérica", recuperacao: "cinco", wantErr: true},
+		{name: "arredondamento desconhecido", arredondamento: "truncar", wantErr: true},
+		{name: "casas decimais negativas", casas: "-1", wantErr: true},
+		{name: "casas decimais não numéricas", casas: "uma", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MEDIA_APROVACAO", tt.aprovacao)
+			t.Setenv("MEDIA_RECUPERACAO", tt.recuperacao)
+			t.Setenv("ARREDONDAMENTO_MEDIA", tt.arredondamento)
+			t.Setenv("CASAS_DECIMAIS_MEDIA", tt.casas)
+			t.Setenv("POLITICA_AVALIACAO", "")
+			t.Setenv("POLITICA_AVALIACAO_SALAS", "")
+			t.Setenv("PESOS_BIMESTRE", "")
+
+			got, err := LoadCriteriosAprovacao()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCriteriosAprovacao error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.MediaAprovacao != tt.want.MediaAprovacao || got.MediaRecuperacao != tt.want.MediaRecuperacao ||
+				got.Arredondamento != tt.want.Arredondamento || got.CasasDecimais != tt.want.CasasDecimais {
+				t.Errorf("LoadCriteriosAprovacao = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalcularDesempenhoSemNotas(t *testing.T) {
+	s := &alunoService{criterios: DefaultCriteriosAprovacao()}
+	media, situacao := s.calcularDesempenho(&models.Aluno{ID: 1, NumeroSala: 101}, nil)
+	if media != nil || situacao != nil {
+		t.Errorf("calcularDesempenho without avaliações = %v, %v, want nil", media, situacao)
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+type DisciplinaService interface {
+	GetAllDisciplinas(ctx context.Context) ([]models.Disciplina, error)
+	GetDisciplinaByID(ctx context.Context, id int) (*models.Disciplina, error)
+	CreateDisciplina(ctx context.Context, disciplina *models.Disciplina) error
+	UpdateDisciplina(ctx context.Context, disciplina *models.Disciplina) error
+	DeleteDisciplina(ctx context.Context, id int) error
+}
+
+type disciplinaService struct {
+	repo   repository.DisciplinaRepository
+	policy policy.Policy
+}
+
+// NewDisciplinaService cria o serviço de disciplinas, consultadas por qualquer usuário autenticado e
+// alteradas por quem a policy permite gerenciar os cadastros.
+func NewDisciplinaService(repo repository.DisciplinaRepository, policy policy.Policy) DisciplinaService {
+	return &disciplinaService{repo, policy}
+}
+
+func (s *disciplinaService) GetAllDisciplinas(ctx context.Context) ([]models.Disciplina, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *disciplinaService) GetDisciplinaByID(ctx context.Context, id int) (*models.Disciplina, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *disciplinaService) CreateDisciplina(ctx context.Context, disciplina *models.Disciplina) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	disciplina.Nome = strings.TrimSpace(disciplina.Nome)
+	if err := validateDisciplina(disciplina); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, disciplina)
+}
+
+func (s *disciplinaService) UpdateDisciplina(ctx context.Context, disciplina *models.Disciplina) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	disciplina.Nome = strings.TrimSpace(disciplina.Nome)
+	if err := validateDisciplina(disciplina); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, disciplina)
+}
+
+func (s *disciplinaService) DeleteDisciplina(ctx context.Context, id int) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+// GradingPolicy calcula a média de um aluno em uma disciplina a partir das avaliações dela.
+// Sem avaliações a média é 0, mas os alunos sem notas não têm média (calcularDesempenho).
+type GradingPolicy interface {
+	Nome() string
+	Media(notas []models.Avaliacao) float64
+}
+
+// Nomes das políticas aceitos em POLITICA_AVALIACAO e POLITICA_AVALIACAO_SALAS.
+const (
+	PoliticaMediaSimples              = "media_simples"
+	PoliticaMediaPonderada            = "media_ponderada"
+	PoliticaDescartaMenor             = "descarta_menor"
+	PoliticaRecuperacaoSubstituiMenor = "recuperacao_substitui_menor"
+)
+
+func mediaAritmetica(valores []float64) float64 {
+	if len(valores) == 0 {
+		return 0
+	}
+	var soma float64
+	for _, v := range valores {
+		soma += v
+	}
+	return soma / float64(len(valores))
+}
+
+func valoresDasNotas(notas []models.Avaliacao) []float64 {
+	valores := make([]float64, len(notas))
+	for i, n := range notas {
+		valores[i] = n.Nota
+	}
+	return valores
+}
+
+// MediaSimples é a média aritmética de todas as avaliações, incluindo as de recuperação.
+type MediaSimples struct{}
+
+func (MediaSimples) Nome() string { return PoliticaMediaSimples }
+
+func (MediaSimples) Media(notas []models.Avaliacao) float64 {
+	return mediaAritmetica(valoresDasNotas(notas))
+}
+
+// MediaPonderada pondera cada avaliação pelo peso do seu bimestre (PesosBimestre[0] é o 1º bimestre).
+type MediaPonderada struct {
+	PesosBimestre [4]float64
+}
+
+func (MediaPonderada) Nome() string { return PoliticaMediaPonderada }
+
+func (p MediaPonderada) Media(notas []models.Avaliacao) float64 {
+	var soma, pesos float64
+	for _, n := range notas {
+		peso := p.PesosBimestre[n.Bimestre-1]
+		soma += n.Nota * peso
+		pesos += peso
+	}
+	if pesos == 0 {
+		return mediaAritmetica(valoresDasNotas(notas))
+	}
+	return soma / pesos
+}
+
+// DescartaMenor descarta a menor nota antes de tirar a média, quando há mais de uma avaliação.
+type DescartaMenor struct{}
+
+func (DescartaMenor) Nome() string { return PoliticaDescartaMenor }
+
+func (DescartaMenor) Media(notas []models.Avaliacao) float64 {
+	valores := valoresDasNotas(notas)
+	if len(valores) <= 1 {
+		return mediaAritmetica(valores)
+	}
+	sort.Float64s(valores)
+	return mediaAritmetica(valores[1:])
+}
+
+// RecuperacaoSubstituiMenor usa a maior nota de recuperação no lugar da menor nota regular,
+// se ela for maior, e tira a média das notas regulares.
+type RecuperacaoSubstituiMenor struct{}
+
+func (RecuperacaoSubstituiMenor) Nome() string { return PoliticaRecuperacaoSubstituiMenor }
+
+func (RecuperacaoSubstituiMenor) Media(notas []models.Avaliacao) float64 {
+	var regulares []float64
+	recuperacao, temRecuperacao := 0.0, false
+	for _, n := range notas {
+		if !n.Recuperacao {
+			regulares = append(regulares, n.Nota)
+		} else if !temRecuperacao || n.Nota > recuperacao {
+			recuperacao, temRecuperacao = n.Nota, true
+		}
+	}
+	if len(regulares) == 0 {
+		return recuperacao
+	}
+	if temRecuperacao {
+		sort.Float64s(regulares)
+		if recuperacao > regulares[0] {
+			regulares[0] = recuperacao
+		}
+	}
+	return mediaAritmetica(regulares)
+}
+
+// PoliticasAvaliacao escolhe a GradingPolicy de cada turma (sala), com uma política padrão
+// para as salas sem configuração própria.
+type PoliticasAvaliacao struct {
+	Padrao  GradingPolicy
+	PorSala map[int]GradingPolicy
+}
+
+func (p PoliticasAvaliacao) Para(numeroSala int) GradingPolicy {
+	if policy, ok := p.PorSala[numeroSala]; ok {
+		return policy
+	}
+	if p.Padrao == nil {
+		return MediaSimples{}
+	}
+	return p.Padrao
+}
+
+func newGradingPolicy(nome string, pesos [4]float64) (GradingPolicy, error) {
+	switch nome {
+	case PoliticaMediaSimples:
+		return MediaSimples{}, nil
+	case PoliticaMediaPonderada:
+		return MediaPonderada{PesosBimestre: pesos}, nil
+	case PoliticaDescartaMenor:
+		return DescartaMenor{}, nil
+	case PoliticaRecuperacaoSubstituiMenor:
+		return RecuperacaoSubstituiMenor{}, nil
+	default:
+		return nil, fmt.Errorf("política de avaliação desconhecida: %q", nome)
+	}
+}
+
+// LoadPoliticasAvaliacao lê POLITICA_AVALIACAO (política padrão), POLITICA_AVALIACAO_SALAS
+// (exceções por sala, no formato "101=descarta_menor,204=media_ponderada") e PESOS_BIMESTRE
+// (pesos da média ponderada, no formato "1,1,2,2").
+func LoadPoliticasAvaliacao() (PoliticasAvaliacao, error) {
+	politicas := PoliticasAvaliacao{Padrao: MediaSimples{}, PorSala: map[int]GradingPolicy{}}
+
+	pesos := [4]float64{1, 1, 1, 1}
+	if v := os.Getenv("PESOS_BIMESTRE"); v != "" {
+		partes := strings.Split(v, ",")
+		if len(partes) != len(pesos) {
+			return politicas, fmt.Errorf("PESOS_BIMESTRE deve ter %d pesos: %q", len(pesos), v)
+		}
+		for i, parte := range partes {
+			peso, err := strconv.ParseFloat(strings.TrimSpace(parte), 64)
+			if err != nil || peso < 0 {
+				return politicas, fmt.Errorf("PESOS_BIMESTRE inválido: %q", v)
+			}
+			pesos[i] = peso
+		}
+	}
+
+	if v := os.Getenv("POLITICA_AVALIACAO"); v != "" {
+		policy, err := newGradingPolicy(strings.TrimSpace(v), pesos)
+		if err != nil {
+			return politicas, err
+		}
+		politicas.Padrao = policy
+	}
+
+	if v := os.Getenv("POLITICA_AVALIACAO_SALAS"); v != "" {
+		for _, item := range strings.Split(v, ",") {
+			sala, nome, ok := strings.Cut(strings.TrimSpace(item), "=")
+			numero, err := strconv.Atoi(strings.TrimSpace(sala))
+			if !ok || err != nil {
+				return politicas, fmt.Errorf("POLITICA_AVALIACAO_SALAS inválida: %q", item)
+			}
+			policy, err := newGradingPolicy(strings.TrimSpace(nome), pesos)
+			if err != nil {
+				return politicas, err
+			}
+			politicas.PorSala[numero] = policy
+		}
+	}
+
+	return politicas, nil
+}
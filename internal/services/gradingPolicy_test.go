@@ -0,0 +1,116 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+func regular(bimestre int, nota float64) models.Avaliacao {
+	return models.Avaliacao{Bimestre: bimestre, Nota: nota}
+}
+
+func recuperacao(bimestre int, nota float64) models.Avaliacao {
+	return models.Avaliacao{Bimestre: bimestre, Nota: nota, Recuperacao: true}
+}
+
+func TestGradingPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy GradingPolicy
+		notas  []models.Avaliacao
+		want   float64
+	}{
+		{name: "média simples", policy: MediaSimples{}, notas: []models.Avaliacao{regular(1, 6), regular(2, 8)}, want: 7},
+		{name: "média simples inclui a recuperação", policy: MediaSimples{}, notas: []models.Avaliacao{regular(1, 4), recuperacao(1, 7)}, want: 5.5},
+		{name: "média simples sem avaliações", policy: MediaSimples{}, want: 0},
+
+		{name: "média ponderada", policy: MediaPonderada{PesosBimestre: [4]float64{1, 1, 2, 2}},
+			notas: []models.Avaliacao{regular(1, 4), regular(3, 7)}, want: 6},
+		{name: "média ponderada ignora bimestre com peso 0", policy: MediaPonderada{PesosBimestre: [4]float64{0, 1, 1, 1}},
+			notas: []models.Avaliacao{regular(1, 0), regular(2, 8)}, want: 8},
+		{name: "pesos das avaliações somam 0", policy: MediaPonderada{PesosBimestre: [4]float64{0, 0, 1, 1}},
+			notas: []models.Avaliacao{regular(1, 5), regular(2, 9)}, want: 7},
+		{name: "média ponderada sem avaliações", policy: MediaPonderada{PesosBimestre: [4]float64{1, 1, 1, 1}}, want: 0},
+
+		{name: "descarta a menor", policy: DescartaMenor{}, notas: []models.Avaliacao{regular(1, 2), regular(2, 8), regular(3, 6)}, want: 7},
+		{name: "descarta só uma das menores repetidas", policy: DescartaMenor{},
+			notas: []models.Avaliacao{regular(1, 3), regular(2, 3), regular(3, 9)}, want: 6},
+		{name: "descarta a menor com uma avaliação", policy: DescartaMenor{}, notas: []models.Avaliacao{regular(1, 4)}, want: 4},
+		{name: "descarta a menor sem avaliações", policy: DescartaMenor{}, want: 0},
+
+		{name: "recuperação substitui a menor", policy: RecuperacaoSubstituiMenor{},
+			notas: []models.Avaliacao{regular(1, 3), regular(2, 8), recuperacao(1, 7)}, want: 7.5},
+		{name: "recuperação menor que a menor nota", policy: RecuperacaoSubstituiMenor{},
+			notas: []models.Avaliacao{regular(1, 5), regular(2, 8), recuperacao(1, 4)}, want: 6.5},
+		{name: "maior das recuperações", policy: RecuperacaoSubstituiMenor{},
+			notas: []models.Avaliacao{regular(1, 3), regular(2, 8), recuperacao(1, 9), recuperacao(2, 6)}, want: 8.5},
+		{name: "só recuperação", policy: RecuperacaoSubstituiMenor{}, notas: []models.Avaliacao{recuperacao(1, 6)}, want: 6},
+		{name: "sem recuperação", policy: RecuperacaoSubstituiMenor{}, notas: []models.Avaliacao{regular(1, 5), regular(2, 6)}, want: 5.5},
+		{name: "recuperação sem avaliações", policy: RecuperacaoSubstituiMenor{}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Media(tt.notas); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("%s.Media = %v, want %v", tt.policy.Nome(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPoliticasAvaliacao(t *testing.T) {
+	tests := []struct {
+		name    string
+		padrao  string
+		salas   string
+		pesos   string
+		want    map[int]string // política de cada sala; a chave 0 é uma sala sem exceção
+		wantErr bool
+	}{
+		{name: "padrão", want: map[int]string{0: PoliticaMediaSimples}},
+		{name: "política padrão e exceções", padrao: "descarta_menor", salas: " 101=media_ponderada , 204 = recuperacao_substitui_menor",
+			want: map[int]string{0: PoliticaDescartaMenor, 101: PoliticaMediaPonderada, 204: PoliticaRecuperacaoSubstituiMenor}},
+		{name: "pesos com espaços", padrao: "media_ponderada", pesos: "1, 1, 2, 2", want: map[int]string{0: PoliticaMediaPonderada}},
+		{name: "pesos zerados", padrao: "media_ponderada", pesos: "0,0,0,0", want: map[int]string{0: PoliticaMediaPonderada}},
+		{name: "política desconhecida", padrao: "media_geometrica", wantErr: true},
+		{name: "política da sala desconhecida", salas: "101=media_geometrica", wantErr: true},
+		{name: "sala sem política", salas: "101", wantErr: true},
+		{name: "sala não numérica", salas: "A1=descarta_menor", wantErr: true},
+		{name: "pesos a menos", pesos: "1,1,2", wantErr: true},
+		{name: "peso negativo", pesos: "1,1,-2,2", wantErr: true},
+		{name: "peso não numérico", pesos: "1,1,dois,2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("POLITICA_AVALIACAO", tt.padrao)
+			t.Setenv("POLITICA_AVALIACAO_SALAS", tt.salas)
+			t.Setenv("PESOS_BIMESTRE", tt.pesos)
+
+			politicas, err := LoadPoliticasAvaliacao()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPoliticasAvaliacao error = %v, want error %v", err, tt.wantErr)
+			}
+			for sala, want := range tt.want {
+				if got := politicas.Para(sala).Nome(); got != want {
+					t.Errorf("Para(%d) = %s, want %s", sala, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadPoliticasAvaliacaoPesos(t *testing.T) {
+	t.Setenv("POLITICA_AVALIACAO", PoliticaMediaPonderada)
+	t.Setenv("POLITICA_AVALIACAO_SALAS", "")
+	t.Setenv("PESOS_BIMESTRE", "1,1,2,2")
+
+	politicas, err := LoadPoliticasAvaliacao()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MediaPonderada{PesosBimestre: [4]float64{1, 1, 2, 2}}
+	if politicas.Padrao != want {
+		t.Errorf("Padrao = %#v, want %#v", politicas.Padrao, want)
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+type ProfessorService interface {
+	GetAllProfessores(ctx context.Context) ([]models.Professor, error)
+	GetProfessorByID(ctx context.Context, id int) (*models.Professor, error)
+	CreateProfessor(ctx context.Context, professor *models.Professor) error
+	UpdateProfessor(ctx context.Context, professor *models.Professor) error
+	DeleteProfessor(ctx context.Context, id int) error
+}
+
+type professorService struct {
+	repo   repository.ProfessorRepository
+	policy policy.Policy
+}
+
+// NewProfessorService cria o serviço de professores, consultados por qualquer usuário autenticado e
+// alterados por quem a policy permite gerenciar os cadastros.
+func NewProfessorService(repo repository.ProfessorRepository, policy policy.Policy) ProfessorService {
+	return &professorService{repo, policy}
+}
+
+// tituloProfessor casa o título no início do nome, o mesmo removido por normalizar_nome_professor.
+var tituloProfessor = regexp.MustCompile(`(?i)^\s*prof(essora|essor|a)?\.?\s+`)
+
+// nomeProfessor remove o título e espaços redundantes do nome informado pelo cliente.
+func nomeProfessor(nome string) string {
+	return strings.Join(strings.Fields(tituloProfessor.ReplaceAllString(nome, "")), " ")
+}
+
+func (s *professorService) GetAllProfessores(ctx context.Context) ([]models.Professor, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *professorService) GetProfessorByID(ctx context.Context, id int) (*models.Professor, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *professorService) CreateProfessor(ctx context.Context, professor *models.Professor) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	professor.Nome = nomeProfessor(professor.Nome)
+	if err := validateProfessor(professor); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, professor)
+}
+
+func (s *professorService) UpdateProfessor(ctx context.Context, professor *models.Professor) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	professor.Nome = nomeProfessor(professor.Nome)
+	if err := validateProfessor(professor); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, professor)
+}
+
+func (s *professorService) DeleteProfessor(ctx context.Context, id int) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
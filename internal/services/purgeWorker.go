@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/actor"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// ConfigExpurgo define por quanto tempo os alunos removidos são mantidos (e podem ser
+// restaurados) e de quanto em quanto tempo o expurgo é executado.
+type ConfigExpurgo struct {
+	Retencao  time.Duration
+	Intervalo time.Duration
+}
+
+func DefaultConfigExpurgo() ConfigExpurgo {
+	return ConfigExpurgo{Retencao: 30 * 24 * time.Hour, Intervalo: time.Hour}
+}
+
+// LoadConfigExpurgo lê a configuração das variáveis RETENCAO_ALUNOS_REMOVIDOS e INTERVALO_EXPURGO
+// (durações no formato do Go, como 720h ou 30m), usando os valores padrão para as ausentes.
+func LoadConfigExpurgo() (ConfigExpurgo, error) {
+	c := DefaultConfigExpurgo()
+	vars := map[string]*time.Duration{
+		"RETENCAO_ALUNOS_REMOVIDOS": &c.Retencao,
+		"INTERVALO_EXPURGO":         &c.Intervalo,
+	}
+	for name, dest := range vars {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return c, fmt.Errorf("%s inválido: %q", name, v)
+		}
+		*dest = d
+	}
+	return c, nil
+}
+
+// PurgeWorker exclui definitivamente, periodicamente, os alunos removidos há mais tempo que a
+// retenção. Depois disso eles não podem mais ser restaurados, os seus dados pessoais deixam o
+// histórico e a auditoria, e a sincronização os informa apenas como removidos.
+type PurgeWorker struct {
+	repo   repository.AlunoRepository
+	config ConfigExpurgo
+	logger *logrus.Logger
+}
+
+func NewPurgeWorker(repo repository.AlunoRepository, config ConfigExpurgo, logger *logrus.Logger) *PurgeWorker {
+	return &PurgeWorker{repo, config, logger}
+}
+
+// Run executa o expurgo imediatamente e depois a cada intervalo, até ctx ser cancelado.
+// Os expurgos são registrados na auditoria em nome de actor.System.
+func (w *PurgeWorker) Run(ctx context.Context) {
+	ctx = actor.WithActor(ctx, actor.System)
+	ticker := time.NewTicker(w.config.Intervalo)
+	defer ticker.Stop()
+	for {
+		w.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *PurgeWorker) purge(ctx context.Context) {
+	before := time.Now().Add(-w.config.Retencao)
+	n, err := w.repo.Purge(ctx, before)
+	if err != nil {
+		w.logger.WithError(err).Error("Failed to purge deleted students")
+		return
+	}
+	if n > 0 {
+		w.logger.WithFields(logrus.Fields{"purged": n, "deleted_before": before}).Info("Purged deleted students")
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+type SalaService interface {
+	GetAllSalas(ctx context.Context) ([]models.Sala, error)
+	GetSalaByID(ctx context.Context, id int) (*models.Sala, error)
+	CreateSala(ctx context.Context, sala *models.Sala) error
+	UpdateSala(ctx context.Context, sala *models.Sala) error
+	DeleteSala(ctx context.Context, id int) error
+}
+
+type salaService struct {
+	repo   repository.SalaRepository
+	policy policy.Policy
+}
+
+// NewSalaService cria o serviço de salas, consultadas por qualquer usuário autenticado e
+// alteradas por quem a policy permite gerenciar os cadastros.
+func NewSalaService(repo repository.SalaRepository, policy policy.Policy) SalaService {
+	return &salaService{repo, policy}
+}
+
+func (s *salaService) GetAllSalas(ctx context.Context) ([]models.Sala, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *salaService) GetSalaByID(ctx context.Context, id int) (*models.Sala, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *salaService) CreateSala(ctx context.Context, sala *models.Sala) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	sala.Predio = strings.TrimSpace(sala.Predio)
+	if err := validateSala(sala); err != nil {
+		return err
+	}
+	sala.Ocupacao = 0
+	return s.repo.Create(ctx, sala)
+}
+
+func (s *salaService) UpdateSala(ctx context.Context, sala *models.Sala) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	sala.Predio = strings.TrimSpace(sala.Predio)
+	if err := validateSala(sala); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, sala)
+}
+
+func (s *salaService) DeleteSala(ctx context.Context, id int) error {
+	if err := s.policy.Authorize(ctx, policy.GerenciarCadastros); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
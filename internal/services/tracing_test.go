@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	spanRecorder     = tracetest.NewSpanRecorder()
+	spanRecorderOnce sync.Once
+)
+
+// tracerProvider registra o provider global dos testes uma única vez: o tracer do pacote fica
+// ligado ao primeiro provider registrado.
+func tracerProvider() trace.TracerProvider {
+	spanRecorderOnce.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	})
+	return otel.GetTracerProvider()
+}
+
+// spanContexts guarda o span do contexto recebido por cada método dos repositórios.
+type spanContexts map[string]trace.SpanContext
+
+type alunoRepoTraceStub struct {
+	repository.AlunoRepository
+	spans spanContexts
+}
+
+func (r alunoRepoTraceStub) GetByID(ctx context.Context, id int) (*models.Aluno, error) {
+	r.spans["aluno.GetByID"] = trace.SpanContextFromContext(ctx)
+	return &models.Aluno{ID: id, Nome: "Ana", Idade: 15, NumeroSala: 101, Version: 1}, nil
+}
+
+func (r alunoRepoTraceStub) Create(ctx context.Context, aluno *models.Aluno) error {
+	r.spans["aluno.Create"] = trace.SpanContextFromContext(ctx)
+	aluno.ID = 1
+	return nil
+}
+
+func (r alunoRepoTraceStub) UpdateSituacao(ctx context.Context, aluno *models.Aluno) error {
+	r.spans["aluno.UpdateSituacao"] = trace.SpanContextFromContext(ctx)
+	return nil
+}
+
+type avaliacaoRepoTraceStub struct {
+	repository.AvaliacaoRepository
+	spans spanContexts
+}
+
+func (r avaliacaoRepoTraceStub) ListByAluno(ctx context.Context, alunoID int) ([]models.Avaliacao, error) {
+	r.spans["avaliacao.ListByAluno"] = trace.SpanContextFromContext(ctx)
+	return []models.Avaliacao{{AlunoID: alunoID, DisciplinaID: 1, Bimestre: 1, Descricao: "Prova", Nota: 8}}, nil
+}
+
+type salaRepoTraceStub struct {
+	repository.SalaRepository
+	spans spanContexts
+}
+
+func (r salaRepoTraceStub) GetByNumero(ctx context.Context, numero int) (*models.Sala, error) {
+	r.spans["sala.GetByNumero"] = trace.SpanContextFromContext(ctx)
+	return &models.Sala{Numero: numero, Capacidade: 40}, nil
+}
+
+type professorRepoTraceStub struct {
+	repository.ProfessorRepository
+	spans spanContexts
+}
+
+func (r professorRepoTraceStub) GetByID(ctx context.Context, id int) (*models.Professor, error) {
+	r.spans["professor.GetByID"] = trace.SpanContextFromContext(ctx)
+	return &models.Professor{ID: id, Nome: "Prof. Silva"}, nil
+}
+
+// TestTracePropagation verifica que o contexto do serviço, com o span da operação, chega a
+// todos os repositórios chamados por ela.
+func TestTracePropagation(t *testing.T) {
+	tests := []struct {
+		name      string
+		span      string
+		run       func(ctx context.Context, s AlunoService) error
+		wantCalls []string
+	}{
+		{
+			name:      "AtualizarSituacao",
+			span:      "alunoService.AtualizarSituacao",
+			run:       func(ctx context.Context, s AlunoService) error { return s.AtualizarSituacao(ctx, 1) },
+			wantCalls: []string{"aluno.GetByID", "avaliacao.ListByAluno", "aluno.UpdateSituacao"},
+		},
+		{
+			name: "CreateAluno",
+			span: "alunoService.CreateAluno",
+			run: func(ctx context.Context, s AlunoService) error {
+				professorID := 3
+				return s.CreateAluno(ctx, &models.Aluno{Nome: "Ana", Idade: 15, NumeroSala: 101, ProfessorID: &professorID})
+			},
+			wantCalls: []string{"sala.GetByNumero", "professor.GetByID", "aluno.Create"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans := spanContexts{}
+			s := NewAlunoService(alunoRepoTraceStub{spans: spans}, professorRepoTraceStub{spans: spans},
+				salaRepoTraceStub{spans: spans}, avaliacaoRepoTraceStub{spans: spans},
+				DefaultCriteriosAprovacao(), policy.NewRBAC())
+
+			ctx, root := tracerProvider().Tracer("test").Start(policy.SystemContext(context.Background()), tt.name)
+			if err := tt.run(ctx, s); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			root.End()
+
+			var service sdktrace.ReadOnlySpan
+			for _, span := range spanRecorder.Ended() {
+				if span.SpanContext().TraceID() == root.SpanContext().TraceID() && span.Name() == tt.span {
+					service = span
+				}
+			}
+			if service == nil {
+				t.Fatalf("span %s was not recorded in the request trace", tt.span)
+			}
+			if service.Parent().SpanID() != root.SpanContext().SpanID() {
+				t.Errorf("%s parent = %s, want the request span %s", tt.span, service.Parent().SpanID(), root.SpanContext().SpanID())
+			}
+			for _, call := range tt.wantCalls {
+				sc, ok := spans[call]
+				if !ok {
+					t.Errorf("%s was not called", call)
+					continue
+				}
+				if !sc.Equal(service.SpanContext()) {
+					t.Errorf("%s received span %s/%s, want the %s span %s/%s",
+						call, sc.TraceID(), sc.SpanID(), tt.span, service.SpanContext().TraceID(), service.SpanContext().SpanID())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+)
+
+// rule verifica o valor de um campo e retorna a violação encontrada, ou nil. O erro só é
+// preenchido quando a própria verificação falha (por exemplo, uma consulta ao banco).
+// O campo da violação é preenchido por field.
+type rule[T any] func(value T) (*models.FieldError, error)
+
+// fieldCheck aplica as regras de um campo, parando na primeira violação.
+type fieldCheck func() (*models.FieldError, error)
+
+// field declara as regras de um campo. As regras são avaliadas em ordem e apenas a primeira
+// violação de cada campo é reportada.
+func field[T any](name string, value T, rules ...rule[T]) fieldCheck {
+	return func() (*models.FieldError, error) {
+		for _, r := range rules {
+			violation, err := r(value)
+			if err != nil || violation != nil {
+				if violation != nil {
+					violation.Field = name
+				}
+				return violation, err
+			}
+		}
+		return nil, nil
+	}
+}
+
+// validate avalia todos os campos e retorna um *models.ValidationError com as violações encontradas.
+func validate(checks ...fieldCheck) error {
+	var violations []models.FieldError
+	for _, check := range checks {
+		violation, err := check()
+		if err != nil {
+			return err
+		}
+		if violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+	if len(violations) > 0 {
+		return &models.ValidationError{Errors: violations}
+	}
+	return nil
+}
+
+// newViolation cria um erro de validação com uma única violação, para as regras verificadas fora de validate.
+func newViolation(fieldError models.FieldError) error {
+	return &models.ValidationError{Errors: []models.FieldError{fieldError}}
+}
+
+// fieldError cria a violação de uma regra; o campo é preenchido por field.
+func fieldError(code, key string, args ...interface{}) *models.FieldError {
+	fe := models.NewFieldError("", code, key, args...)
+	return &fe
+}
+
+func required() rule[string] {
+	return func(value string) (*models.FieldError, error) {
+		if strings.TrimSpace(value) == "" {
+			return fieldError(models.CodeRequired, "validation.required"), nil
+		}
+		return nil, nil
+	}
+}
+
+// maxLength limita o tamanho em caracteres, como nas colunas VARCHAR do banco.
+func maxLength(max int) rule[string] {
+	return func(value string) (*models.FieldError, error) {
+		if utf8.RuneCountInString(value) > max {
+			return fieldError(models.CodeMaxLength, "validation.max_length", max), nil
+		}
+		return nil, nil
+	}
+}
+
+func between[T cmp.Ordered](min, max T) rule[T] {
+	return func(value T) (*models.FieldError, error) {
+		if value < min || value > max {
+			return fieldError(models.CodeOutOfRange, "validation.out_of_range", min, max), nil
+		}
+		return nil, nil
+	}
+}
+
+// salaCadastrada verifica se a sala informada existe.
+func (s *alunoService) salaCadastrada(ctx context.Context) rule[int] {
+	return func(numero int) (*models.FieldError, error) {
+		_, err := s.salaRepo.GetByNumero(ctx, numero)
+		if errors.Is(err, models.ErrSalaNotFound) {
+			return salaNaoCadastrada(numero), nil
+		}
+		return nil, err
+	}
+}
+
+func salaNaoCadastrada(numero int) *models.FieldError {
+	fe := models.NewFieldError("numero_sala", models.CodeNotFound, "validation.sala_not_found", numero)
+	return &fe
+}
+
+// validateAluno aplica as regras dos campos do aluno. A existência da sala só é verificada
+// quando ela é atribuída ou alterada, e o nome do professor só quando ele é informado sem professor_id.
+func (s *alunoService) validateAluno(ctx context.Context, aluno *models.Aluno, novaSala bool) error {
+	checks := []fieldCheck{
+		field("nome", aluno.Nome, required(), maxLength(models.MaxNomeLength)),
+		field("idade", aluno.Idade, between(models.IdadeMinima, models.IdadeMaxima)),
+	}
+	if aluno.ProfessorID == nil {
+		checks = append(checks, field("nome_professor", nomeProfessor(aluno.NomeProfessor), maxLength(models.MaxNomeLength)))
+	}
+	if novaSala {
+		checks = append(checks, field("numero_sala", aluno.NumeroSala, s.salaCadastrada(ctx)))
+	}
+	return validate(checks...)
+}
+
+func validateAvaliacao(avaliacao *models.Avaliacao) error {
+	return validate(
+		field("bimestre", avaliacao.Bimestre, between(models.BimestreMinimo, models.BimestreMaximo)),
+		field("descricao", avaliacao.Descricao, required(), maxLength(models.MaxDescricaoLength)),
+		field("nota", avaliacao.Nota, between(models.NotaMinima, models.NotaMaxima)),
+	)
+}
+
+// Os nomes dos cadastros são validados depois de normalizados: "Prof. " não deixa um professor sem nome.
+
+func validateProfessor(professor *models.Professor) error {
+	return validate(field("nome", professor.Nome, required(), maxLength(models.MaxNomeLength)))
+}
+
+func validateSala(sala *models.Sala) error {
+	return validate(field("predio", sala.Predio, maxLength(models.MaxPredioLength)))
+}
+
+func validateDisciplina(disciplina *models.Disciplina) error {
+	return validate(field("nome", disciplina.Nome, required(), maxLength(models.MaxNomeLength)))
+}
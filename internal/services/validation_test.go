@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/models"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
+)
+
+// salaRepoStub conhece apenas as salas informadas; os demais métodos não são usados na validação.
+type salaRepoStub struct {
+	repository.SalaRepository
+	salas map[int]bool
+	err   error
+}
+
+func (r salaRepoStub) GetByNumero(ctx context.Context, numero int) (*models.Sala, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if !r.salas[numero] {
+		return nil, models.ErrSalaNotFound
+	}
+	return &models.Sala{Numero: numero, Capacidade: 40}, nil
+}
+
+// violation é a parte de models.FieldError comparada nos testes, com a mensagem nos dois idiomas.
+type violation struct {
+	Field, Code, PtBR, En string
+}
+
+func violations(t *testing.T, err error) []violation {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var ve *models.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("error = %v, want *models.ValidationError", err)
+	}
+	if !errors.Is(err, models.ErrValidation) {
+		t.Errorf("errors.Is(%v, ErrValidation) = false", err)
+	}
+	got := make([]violation, len(ve.Errors))
+	for i, fe := range ve.Errors {
+		if fe.Message != fe.Localize(i18n.Default).Message {
+			t.Errorf("Message = %q, want the %s message", fe.Message, i18n.Default)
+		}
+		got[i] = violation{fe.Field, fe.Code, fe.Localize(i18n.PtBR).Message, fe.Localize(i18n.En).Message}
+	}
+	return got
+}
+
+func TestValidateAluno(t *testing.T) {
+	valid := func(change func(a *models.Aluno)) *models.Aluno {
+		aluno := &models.Aluno{Nome: "Ana", Idade: 15, NumeroSala: 101}
+		if change != nil {
+			change(aluno)
+		}
+		return aluno
+	}
+	tests := []struct {
+		name     string
+		aluno    *models.Aluno
+		novaSala bool
+		want     []violation
+	}{
+		{name: "válido", aluno: valid(nil), novaSala: true},
+		{
+			name:  "nome em branco",
+			aluno: valid(func(a *models.Aluno) { a.Nome = "  " }),
+			want:  []violation{{"nome", models.CodeRequired, "campo obrigatório", "required field"}},
+		},
+		{
+			name:  "nome longo",
+			aluno: valid(func(a *models.Aluno) { a.Nome = strings.Repeat("á", models.MaxNomeLength+1) }),
+			want:  []violation{{"nome", models.CodeMaxLength, "deve ter no máximo 100 caracteres", "must be at most 100 characters long"}},
+		},
+		{name: "nome no limite, contado em caracteres", aluno: valid(func(a *models.Aluno) { a.Nome = strings.Repeat("á", models.MaxNomeLength) })},
+		{
+			name:  "idade acima do limite",
+			aluno: valid(func(a *models.Aluno) { a.Idade = models.IdadeMaxima + 1 }),
+			want:  []violation{{"idade", models.CodeOutOfRange, "deve estar entre 1 e 120", "must be between 1 and 120"}},
+		},
+		{
+			name:     "sala inexistente",
+			aluno:    valid(func(a *models.Aluno) { a.NumeroSala = 999 }),
+			novaSala: true,
+			want:     []violation{{"numero_sala", models.CodeNotFound, "a sala 999 não está cadastrada", "classroom 999 does not exist"}},
+		},
+		{name: "sala inexistente não verificada sem troca", aluno: valid(func(a *models.Aluno) { a.NumeroSala = 999 })},
+		{
+			name:  "nome do professor longo, sem professor_id",
+			aluno: valid(func(a *models.Aluno) { a.NomeProfessor = "Prof. " + strings.Repeat("x", models.MaxNomeLength+1) }),
+			want:  []violation{{"nome_professor", models.CodeMaxLength, "deve ter no máximo 100 caracteres", "must be at most 100 characters long"}},
+		},
+		{
+			name: "nome do professor ignorado com professor_id",
+			aluno: valid(func(a *models.Aluno) {
+				id := 1
+				a.ProfessorID, a.NomeProfessor = &id, strings.Repeat("x", models.MaxNomeLength+1)
+			}),
+		},
+		{
+			name:     "uma violação por campo, na ordem dos campos",
+			aluno:    &models.Aluno{Nome: "", Idade: 0, NumeroSala: 999},
+			novaSala: true,
+			want: []violation{
+				{"nome", models.CodeRequired, "campo obrigatório", "required field"},
+				{"idade", models.CodeOutOfRange, "deve estar entre 1 e 120", "must be between 1 and 120"},
+				{"numero_sala", models.CodeNotFound, "a sala 999 não está cadastrada", "classroom 999 does not exist"},
+			},
+		},
+	}
+	s := &alunoService{salaRepo: salaRepoStub{salas: map[int]bool{101: true}}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violations(t, s.validateAluno(context.Background(), tt.aluno, tt.novaSala))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAlunoRepositoryError(t *testing.T) {
+	errBanco := errors.New("conexão perdida")
+	s := &alunoService{salaRepo: salaRepoStub{err: errBanco}}
+	err := s.validateAluno(context.Background(), &models.Aluno{Nome: "Ana", Idade: 15, NumeroSala: 101}, true)
+	if !errors.Is(err, errBanco) {
+		t.Errorf("error = %v, want %v", err, errBanco)
+	}
+}
+
+func TestValidateAvaliacao(t *testing.T) {
+	tests := []struct {
+		name      string
+		avaliacao models.Avaliacao
+		want      []violation
+	}{
+		{name: "válida", avaliacao: models.Avaliacao{Bimestre: 1, Descricao: "Prova", Nota: 10}},
+		{name: "nota mínima", avaliacao: models.Avaliacao{Bimestre: 4, Descricao: "Prova", Nota: 0}},
+		{
+			name:      "bimestre fora do intervalo",
+			avaliacao: models.Avaliacao{Bimestre: 5, Descricao: "Prova", Nota: 7},
+			want:      []violation{{"bimestre", models.CodeOutOfRange, "deve estar entre 1 e 4", "must be between 1 and 4"}},
+		},
+		{
+			name:      "nota fora do intervalo",
+			avaliacao: models.Avaliacao{Bimestre: 1, Descricao: "Prova", Nota: 10.5},
+			want:      []violation{{"nota", models.CodeOutOfRange, "deve estar entre 0 e 10", "must be between 0 and 10"}},
+		},
+		{
+			name:      "descrição longa",
+			avaliacao: models.Avaliacao{Bimestre: 1, Descricao: strings.Repeat("x", models.MaxDescricaoLength+1), Nota: 7},
+			want:      []violation{{"descricao", models.CodeMaxLength, "deve ter no máximo 100 caracteres", "must be at most 100 characters long"}},
+		},
+		{
+			name:      "todos os campos inválidos",
+			avaliacao: models.Avaliacao{Nota: -1},
+			want: []violation{
+				{"bimestre", models.CodeOutOfRange, "deve estar entre 1 e 4", "must be between 1 and 4"},
+				{"descricao", models.CodeRequired, "campo obrigatório", "required field"},
+				{"nota", models.CodeOutOfRange, "deve estar entre 0 e 10", "must be between 0 and 10"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violations(t, validateAvaliacao(&tt.avaliacao))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := validateAvaliacao(&models.Avaliacao{Bimestre: 0, Descricao: "", Nota: 5})
+	want := "dados inválidos: bimestre: deve estar entre 1 e 4; descricao: campo obrigatório"
+	if err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_alunos_numero_sala;
+DROP INDEX IF EXISTS idx_alunos_nome_professor;
+DROP INDEX IF EXISTS idx_alunos_idade;
+DROP INDEX IF EXISTS idx_alunos_nome;
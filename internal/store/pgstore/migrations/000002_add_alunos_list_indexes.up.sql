@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS idx_alunos_nome ON alunos (nome, id);
+CREATE INDEX IF NOT EXISTS idx_alunos_idade ON alunos (idade, id);
+CREATE INDEX IF NOT EXISTS idx_alunos_nome_professor ON alunos (nome_professor, id);
+CREATE INDEX IF NOT EXISTS idx_alunos_numero_sala ON alunos (numero_sala, id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_alunos_nome_trgm;
+DROP INDEX IF EXISTS idx_alunos_busca;
+ALTER TABLE alunos DROP COLUMN IF EXISTS busca;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS busca_alunos;
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
@@ -0,0 +1,20 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() é STABLE; o wrapper com dicionário explícito pode ser usado em índices.
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text AS $$
+    SELECT public.unaccent('public.unaccent'::regdictionary, $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+-- Configuração de busca sem stemming que remove acentos ("Conceição" = "conceicao").
+CREATE TEXT SEARCH CONFIGURATION busca_alunos (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION busca_alunos
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+
+ALTER TABLE alunos ADD COLUMN busca tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('busca_alunos', nome), 'A') ||
+    setweight(to_tsvector('busca_alunos', nome_professor), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_alunos_busca ON alunos USING GIN (busca);
+CREATE INDEX IF NOT EXISTS idx_alunos_nome_trgm ON alunos USING GIN (immutable_unaccent(lower(nome)) gin_trgm_ops);
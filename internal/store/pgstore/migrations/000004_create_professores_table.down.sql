@@ -0,0 +1,20 @@
+ALTER TABLE alunos DROP COLUMN busca;
+ALTER TABLE alunos ADD COLUMN nome_professor VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE alunos a
+SET nome_professor = p.nome
+FROM professores p
+WHERE p.id = a.professor_id;
+
+ALTER TABLE alunos ALTER COLUMN nome_professor DROP DEFAULT;
+ALTER TABLE alunos DROP COLUMN professor_id;
+CREATE INDEX IF NOT EXISTS idx_alunos_nome_professor ON alunos (nome_professor, id);
+
+ALTER TABLE alunos ADD COLUMN busca tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('busca_alunos', nome), 'A') ||
+    setweight(to_tsvector('busca_alunos', nome_professor), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_alunos_busca ON alunos USING GIN (busca);
+
+DROP TABLE IF EXISTS professores;
+DROP FUNCTION IF EXISTS normalizar_nome_professor(text);
@@ -0,0 +1,45 @@
+-- Chave de comparação de nomes de professores: sem acentos, sem maiúsculas, sem o título
+-- ("Prof.", "Profa.", "Professor(a)") e com espaços colapsados, de forma que
+-- "Prof. João  Silva" e "joao silva" identifiquem o mesmo professor.
+CREATE OR REPLACE FUNCTION normalizar_nome_professor(text) RETURNS text AS $$
+    SELECT btrim(regexp_replace(
+        regexp_replace(lower(immutable_unaccent($1)), '^\s*prof(essora|essor|a)?\.?\s+', ''),
+        '\s+', ' ', 'g'))
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+CREATE TABLE IF NOT EXISTS professores (
+    id SERIAL PRIMARY KEY,
+    nome VARCHAR(100) NOT NULL,
+    busca tsvector GENERATED ALWAYS AS (to_tsvector('busca_alunos', nome)) STORED
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_professores_nome_normalizado ON professores (normalizar_nome_professor(nome));
+CREATE INDEX IF NOT EXISTS idx_professores_busca ON professores USING GIN (busca);
+
+-- Um professor por nome normalizado; o nome exibido é a grafia (sem título) mais frequente.
+INSERT INTO professores (nome)
+SELECT DISTINCT ON (chave) nome
+FROM (
+    SELECT normalizar_nome_professor(nome_professor) AS chave,
+           btrim(regexp_replace(nome_professor, '^\s*prof(essora|essor|a)?\.?\s+', '', 'i')) AS nome,
+           COUNT(*) AS ocorrencias
+    FROM alunos
+    GROUP BY 1, 2
+) variantes
+WHERE chave <> ''
+ORDER BY chave, ocorrencias DESC, nome;
+
+ALTER TABLE alunos ADD COLUMN professor_id INT REFERENCES professores (id) ON DELETE SET NULL;
+
+UPDATE alunos a
+SET professor_id = p.id
+FROM professores p
+WHERE normalizar_nome_professor(p.nome) = normalizar_nome_professor(a.nome_professor);
+
+CREATE INDEX IF NOT EXISTS idx_alunos_professor_id ON alunos (professor_id, id);
+
+-- busca passa a indexar apenas o nome do aluno; o nome do professor é buscado em professores.busca.
+ALTER TABLE alunos DROP COLUMN busca;
+ALTER TABLE alunos DROP COLUMN nome_professor;
+ALTER TABLE alunos ADD COLUMN busca tsvector GENERATED ALWAYS AS (to_tsvector('busca_alunos', nome)) STORED;
+CREATE INDEX IF NOT EXISTS idx_alunos_busca ON alunos USING GIN (busca);
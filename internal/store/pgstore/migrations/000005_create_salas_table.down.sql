@@ -0,0 +1,2 @@
+ALTER TABLE alunos DROP CONSTRAINT IF EXISTS fk_alunos_numero_sala;
+DROP TABLE IF EXISTS salas;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS salas (
+    id SERIAL PRIMARY KEY,
+    numero INT NOT NULL UNIQUE,
+    predio VARCHAR(100) NOT NULL DEFAULT '',
+    capacidade INT NOT NULL CHECK (capacidade > 0)
+);
+
+-- Cadastra as salas já usadas pelos alunos. A capacidade inicial é 40, ou a ocupação atual
+-- quando maior, para que nenhuma sala existente nasça acima do limite.
+INSERT INTO salas (numero, capacidade)
+SELECT numero_sala, GREATEST(COUNT(*), 40)
+FROM alunos
+GROUP BY numero_sala;
+
+ALTER TABLE alunos
+    ADD CONSTRAINT fk_alunos_numero_sala FOREIGN KEY (numero_sala)
+    REFERENCES salas (numero) ON UPDATE CASCADE ON DELETE RESTRICT;
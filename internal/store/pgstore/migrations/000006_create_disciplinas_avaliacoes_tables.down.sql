@@ -0,0 +1,20 @@
+ALTER TABLE alunos ADD COLUMN nota_primeiro_semestre FLOAT NOT NULL DEFAULT 0;
+ALTER TABLE alunos ADD COLUMN nota_segundo_semestre FLOAT NOT NULL DEFAULT 0;
+
+UPDATE alunos a
+SET nota_primeiro_semestre = av.nota
+FROM avaliacoes av
+JOIN disciplinas d ON d.id = av.disciplina_id
+WHERE av.aluno_id = a.id AND d.nome = 'Geral' AND av.bimestre = 2 AND av.descricao = 'Nota do 1º semestre';
+
+UPDATE alunos a
+SET nota_segundo_semestre = av.nota
+FROM avaliacoes av
+JOIN disciplinas d ON d.id = av.disciplina_id
+WHERE av.aluno_id = a.id AND d.nome = 'Geral' AND av.bimestre = 4 AND av.descricao = 'Nota do 2º semestre';
+
+ALTER TABLE alunos ALTER COLUMN nota_primeiro_semestre DROP DEFAULT;
+ALTER TABLE alunos ALTER COLUMN nota_segundo_semestre DROP DEFAULT;
+
+DROP TABLE IF EXISTS avaliacoes;
+DROP TABLE IF EXISTS disciplinas;
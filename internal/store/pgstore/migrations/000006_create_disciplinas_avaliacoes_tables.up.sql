@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS disciplinas (
+    id SERIAL PRIMARY KEY,
+    nome VARCHAR(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS avaliacoes (
+    id SERIAL PRIMARY KEY,
+    aluno_id INT NOT NULL REFERENCES alunos (id) ON DELETE CASCADE,
+    disciplina_id INT NOT NULL REFERENCES disciplinas (id) ON DELETE RESTRICT,
+    bimestre SMALLINT NOT NULL CHECK (bimestre BETWEEN 1 AND 4),
+    descricao VARCHAR(100) NOT NULL,
+    nota DOUBLE PRECISION NOT NULL,
+    UNIQUE (aluno_id, disciplina_id, bimestre, descricao)
+);
+
+CREATE INDEX IF NOT EXISTS idx_avaliacoes_disciplina_id ON avaliacoes (disciplina_id);
+
+-- As notas semestrais existentes viram avaliações da disciplina "Geral": a do 1º semestre
+-- no fechamento do 2º bimestre e a do 2º semestre no fechamento do 4º bimestre.
+INSERT INTO disciplinas (nome) VALUES ('Geral') ON CONFLICT (nome) DO NOTHING;
+
+INSERT INTO avaliacoes (aluno_id, disciplina_id, bimestre, descricao, nota)
+SELECT a.id, d.id, 2, 'Nota do 1º semestre', a.nota_primeiro_semestre
+FROM alunos a, disciplinas d
+WHERE d.nome = 'Geral';
+
+INSERT INTO avaliacoes (aluno_id, disciplina_id, bimestre, descricao, nota)
+SELECT a.id, d.id, 4, 'Nota do 2º semestre', a.nota_segundo_semestre
+FROM alunos a, disciplinas d
+WHERE d.nome = 'Geral';
+
+ALTER TABLE alunos DROP COLUMN nota_primeiro_semestre;
+ALTER TABLE alunos DROP COLUMN nota_segundo_semestre;
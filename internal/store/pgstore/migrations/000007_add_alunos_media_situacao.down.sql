@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_alunos_media;
+DROP INDEX IF EXISTS idx_alunos_situacao;
+ALTER TABLE alunos DROP COLUMN IF EXISTS situacao;
+ALTER TABLE alunos DROP COLUMN IF EXISTS media;
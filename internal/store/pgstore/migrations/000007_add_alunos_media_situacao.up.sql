@@ -0,0 +1,7 @@
+-- Média e situação são calculadas pelo serviço a cada lançamento de nota e recalculadas na
+-- inicialização, para que listagens possam filtrar e ordenar por elas sem recalcular tudo.
+ALTER TABLE alunos ADD COLUMN media DOUBLE PRECISION;
+ALTER TABLE alunos ADD COLUMN situacao VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_alunos_situacao ON alunos (situacao, id);
+CREATE INDEX IF NOT EXISTS idx_alunos_media ON alunos (media, id);
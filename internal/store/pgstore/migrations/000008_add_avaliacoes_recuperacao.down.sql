@@ -0,0 +1 @@
+ALTER TABLE avaliacoes DROP COLUMN IF EXISTS recuperacao;
@@ -0,0 +1,2 @@
+-- Marca as avaliações de recuperação, usadas pela política "recuperacao_substitui_menor".
+ALTER TABLE avaliacoes ADD COLUMN recuperacao BOOLEAN NOT NULL DEFAULT FALSE;
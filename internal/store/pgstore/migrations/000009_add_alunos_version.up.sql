@@ -0,0 +1,3 @@
+-- Versão do registro para controle de concorrência otimista: é incrementada a cada alteração
+-- e exposta como ETag; alterações com If-Match só são aplicadas se a versão ainda for a mesma.
+ALTER TABLE alunos ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
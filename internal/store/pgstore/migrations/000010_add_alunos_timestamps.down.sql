@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_alunos_updated_at;
+DELETE FROM alunos WHERE deleted_at IS NOT NULL;
+ALTER TABLE alunos DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE alunos DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE alunos DROP COLUMN IF EXISTS created_at;
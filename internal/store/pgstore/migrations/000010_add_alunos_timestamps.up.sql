@@ -0,0 +1,7 @@
+-- Datas de criação e da última alteração do aluno. Alunos removidos são mantidos com deleted_at
+-- preenchido, para que a sincronização incremental (GET /alunos/changes) informe a remoção.
+ALTER TABLE alunos ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE alunos ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE alunos ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_alunos_updated_at ON alunos (updated_at, id);
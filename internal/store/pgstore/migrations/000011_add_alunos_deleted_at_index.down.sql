@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_alunos_deleted_at;
@@ -0,0 +1,2 @@
+-- Usado pelo expurgo periódico dos alunos removidos há mais tempo que a retenção.
+CREATE INDEX IF NOT EXISTS idx_alunos_deleted_at ON alunos (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Trilha de auditoria das alterações de alunos, gravada na mesma transação da alteração. Cobre
+-- apenas a tabela alunos: notas, professores, salas e disciplinas não são auditados.
+-- Não referencia alunos: as entradas ficam depois que o aluno é expurgado, mas o expurgo esvazia
+-- o diff, que contém dados pessoais.
+-- diff contém apenas os campos alterados: {"campo": {"antes": ..., "depois": ...}}.
+CREATE TABLE IF NOT EXISTS auditoria (
+    id BIGSERIAL PRIMARY KEY,
+    aluno_id INT NOT NULL,
+    ator VARCHAR(100) NOT NULL,
+    operacao VARCHAR(20) NOT NULL,
+    data TIMESTAMPTZ NOT NULL DEFAULT now(),
+    diff JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auditoria_aluno_id ON auditoria (aluno_id, id);
+CREATE INDEX IF NOT EXISTS idx_auditoria_data ON auditoria (data, id);
+CREATE INDEX IF NOT EXISTS idx_auditoria_ator ON auditoria (ator, id);
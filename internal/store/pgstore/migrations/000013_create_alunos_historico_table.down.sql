@@ -0,0 +1 @@
+DROP TABLE IF EXISTS alunos_historico;
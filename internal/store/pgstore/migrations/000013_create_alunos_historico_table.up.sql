@@ -0,0 +1,33 @@
+-- Versões das linhas de alunos, para as consultas "como estava em" (as_of). O repositório grava
+-- uma versão a cada alteração, na mesma transação. Cada versão vale no intervalo
+-- [valid_from, valid_to); a versão atual tem valid_to nulo. nome_professor guarda o nome
+-- do professor na época. As versões são excluídas junto com o aluno, no expurgo.
+CREATE TABLE IF NOT EXISTS alunos_historico (
+    id INT NOT NULL,
+    version INT NOT NULL,
+    nome VARCHAR(100) NOT NULL,
+    idade INT NOT NULL,
+    professor_id INT,
+    nome_professor VARCHAR(100) NOT NULL DEFAULT '',
+    numero_sala INT NOT NULL,
+    media DOUBLE PRECISION,
+    situacao VARCHAR(20),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ,
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ,
+    PRIMARY KEY (id, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_alunos_historico_atual ON alunos_historico (id) WHERE valid_to IS NULL;
+CREATE INDEX IF NOT EXISTS idx_alunos_historico_validade ON alunos_historico (valid_from, valid_to);
+
+-- O histórico começa pela versão atual de cada aluno, válida a partir desta migração: os estados
+-- anteriores não foram registrados, e as consultas as_of de antes dela não encontram o aluno.
+INSERT INTO alunos_historico (id, version, nome, idade, professor_id, nome_professor, numero_sala,
+                              media, situacao, created_at, updated_at, deleted_at, valid_from)
+SELECT a.id, a.version, a.nome, a.idade, a.professor_id, COALESCE(p.nome, ''), a.numero_sala,
+       a.media, a.situacao, a.created_at, a.updated_at, a.deleted_at, now()
+FROM alunos a
+LEFT JOIN professores p ON p.id = a.professor_id;
@@ -0,0 +1,15 @@
+-- Chaves de API das integrações entre sistemas (SIS, BI). Apenas o hash SHA-256 da chave é
+-- armazenado: a chave em claro é exibida uma única vez, na criação. prefixo (o início da chave)
+-- permite reconhecer a chave nas listagens.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    nome VARCHAR(100) NOT NULL,
+    prefixo VARCHAR(16) NOT NULL,
+    hash BYTEA NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    criada_por VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
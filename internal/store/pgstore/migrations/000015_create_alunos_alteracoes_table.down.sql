@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_alunos_alteracoes ON alunos;
+DROP FUNCTION IF EXISTS registrar_alteracao_aluno();
+DROP TABLE IF EXISTS alunos_alteracoes;
@@ -0,0 +1,34 @@
+-- Registro das alterações de alunos que alimenta a sincronização incremental (GET /alunos/changes).
+-- Cada inclusão, alteração ou exclusão de uma linha de alunos grava, pelo gatilho, o id do aluno
+-- e o ID da transação (xid). A sincronização só entrega as alterações das transações anteriores a
+-- todas as que ainda estão em andamento (pg_snapshot_xmin), na ordem (xid, seq): uma transação
+-- que demora a confirmar segura as posteriores, em vez de ser pulada. Não guarda dados pessoais:
+-- as linhas dos alunos expurgados continuam aqui como marcas da exclusão.
+CREATE TABLE IF NOT EXISTS alunos_alteracoes (
+    seq BIGSERIAL PRIMARY KEY,
+    xid XID8 NOT NULL DEFAULT pg_current_xact_id(),
+    aluno_id INT NOT NULL,
+    operacao VARCHAR(6) NOT NULL,
+    data TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alunos_alteracoes_posicao ON alunos_alteracoes (xid, seq);
+
+CREATE OR REPLACE FUNCTION registrar_alteracao_aluno() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO alunos_alteracoes (aluno_id, operacao) VALUES (OLD.id, TG_OP);
+        RETURN OLD;
+    END IF;
+    INSERT INTO alunos_alteracoes (aluno_id, operacao) VALUES (NEW.id, TG_OP);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_alunos_alteracoes
+    AFTER INSERT OR UPDATE OR DELETE ON alunos
+    FOR EACH ROW EXECUTE FUNCTION registrar_alteracao_aluno();
+
+-- A sincronização inicial parte do registro: cada aluno existente entra como criado.
+INSERT INTO alunos_alteracoes (aluno_id, operacao, data)
+SELECT id, 'INSERT', updated_at FROM alunos ORDER BY id;
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
+	"time"
 
 	_ "github.com/felipemacedo1/dev-cloud-challenge/docs" // Importa os documentos gerados pelo swagger
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/auth"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/handlers"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/i18n"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/metrics"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/policy"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/ratelimit"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/repository"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/services"
 	"github.com/felipemacedo1/dev-cloud-challenge/internal/store/pgstore"
@@ -14,6 +22,8 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	logrus "github.com/sirupsen/logrus"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
@@ -27,6 +37,16 @@ import (
 
 // @BasePath /
 // @schemes https
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Token JWT no formato "Bearer <token>"
+
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description Chave de API de integração, criada em /admin/api-keys
 func main() {
 	log := initLogger()
 
@@ -38,6 +58,40 @@ func main() {
 		}
 	}
 
+	// Mascara (ou substitui por hash) os dados pessoais nos logs, conforme o ambiente
+	configRedacao, err := logging.LoadConfigRedacao()
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("Configuração da redação de dados pessoais inválida")
+	}
+	log.AddHook(logging.NewRedactionHook(configRedacao))
+
+	// Exporta os rastros das requisições (OTLP ou saída padrão) quando OTEL_TRACES_EXPORTER é configurado
+	shutdownTracing, err := initTracing(log)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("Falha ao iniciar o rastreamento")
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("Erro ao exportar os rastros pendentes")
+		}
+	}()
+
+	// Métricas em um endereço interno ou, na porta da API, com token (obrigatório em produção)
+	configMetricas, err := metrics.LoadConfig()
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("Configuração das métricas inválida")
+	}
+
 	host := os.Getenv("HOST")
 	if host == "" {
 		host = "dev-cloud-challenge-b3f5485f2dcf.herokuapp.com" // Host padrão para produção
@@ -61,11 +115,99 @@ func main() {
 
 	runMigrations(databaseURL, log)
 
+	// Conexões com o banco e indicadores do cadastro, lidos a cada coleta das métricas
+	prometheus.MustRegister(collectors.NewDBStatsCollector(database, "dev_cloud_challenge"), repository.NewIndicadoresCollector(database))
+
 	alunoRepository := repository.NewAlunoRepository(database)
-	alunoService := services.NewAlunoService(alunoRepository)
+	professorRepository := repository.NewProfessorRepository(database)
+	salaRepository := repository.NewSalaRepository(database)
+	disciplinaRepository := repository.NewDisciplinaRepository(database)
+	avaliacaoRepository := repository.NewAvaliacaoRepository(database)
+	auditoriaRepository := repository.NewAuditoriaRepository(database)
+	apiKeyRepository := repository.NewAPIKeyRepository(database)
+
+	criteriosAprovacao, err := services.LoadCriteriosAprovacao()
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("Critérios de aprovação inválidos")
+	}
+
+	rbac := policy.NewRBAC()
+
+	alunoService := services.NewAlunoService(alunoRepository, professorRepository, salaRepository, avaliacaoRepository, criteriosAprovacao, rbac)
+	professorService := services.NewProfessorService(professorRepository, rbac)
+	salaService := services.NewSalaService(salaRepository, rbac)
+	disciplinaService := services.NewDisciplinaService(disciplinaRepository, rbac)
+	avaliacaoService := services.NewAvaliacaoService(avaliacaoRepository, alunoService, rbac)
+	auditoriaService := services.NewAuditoriaService(auditoriaRepository, alunoRepository, rbac)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepository, rbac)
+
+	// Aplica os critérios de aprovação atuais a quem já tinha notas, em segundo plano para não
+	// atrasar o início do servidor
+	go func() {
+		if atualizados, err := alunoService.RecalcularSituacoes(policy.SystemContext(context.Background())); err != nil {
+			log.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("Falha ao recalcular a situação dos alunos")
+		} else {
+			log.WithField("atualizados", atualizados).Info("Situação dos alunos recalculada")
+		}
+	}()
+
+	configExpurgo, err := services.LoadConfigExpurgo()
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("Configuração do expurgo de alunos removidos inválida")
+	}
+	// Exclui definitivamente os alunos removidos há mais tempo que a retenção
+	go services.NewPurgeWorker(alunoRepository, configExpurgo, log).Run(context.Background())
+
+	authConfig, err := auth.LoadConfig()
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("Configuração de autenticação inválida")
+	}
+
+	configLimites, err := ratelimit.LoadConfig()
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("Configuração dos limites de requisições inválida")
+	}
+
 	alunoHandler := handlers.NewAlunoHandler(alunoService, log)
+	professorHandler := handlers.NewProfessorHandler(professorService, log)
+	salaHandler := handlers.NewSalaHandler(salaService, log)
+	disciplinaHandler := handlers.NewDisciplinaHandler(disciplinaService, log)
+	avaliacaoHandler := handlers.NewAvaliacaoHandler(avaliacaoService, log)
+	auditoriaHandler := handlers.NewAuditoriaHandler(auditoriaService, log)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService, log)
+	problemHandler := handlers.NewProblemHandler(log)
+	docsHandler := handlers.NewDocsHandler(log)
+	healthHandler := handlers.NewHealthHandler(database, log)
+	metricsHandler := handlers.NewMetricsHandler(metrics.Handler(log), configMetricas.Token, log)
+
+	// Atribui o X-Request-ID, o logger da requisição e o log de acesso, inclusive às rotas inexistentes
+	accessLog := logging.Middleware(log)
 
 	router := mux.NewRouter()
+	router.Use(accessLog)
+	router.Use(metrics.Middleware)
+	// Nomeia o span de cada requisição pela rota e registra o trace_id nos logs
+	router.Use(traceRoute)
+	router.Use(i18n.Middleware)
+	limiter := ratelimit.NewLimiter(configLimites, ratelimit.NewMemoryStore())
+	// Limita a taxa de requisições por IP antes da autenticação, inclusive as de credenciais inválidas
+	router.Use(ratelimit.IPMiddleware(limiter))
+	// Exige o token JWT ou a chave de API em todas as rotas, exceto documentação, catálogo de problemas e saúde
+	router.Use(auth.Middleware(auth.NewVerifier(authConfig), apiKeyService))
+	// Limita a taxa de requisições por usuário (ou IP, nas rotas públicas), por rota e por papel
+	router.Use(ratelimit.Middleware(limiter))
+	router.NotFoundHandler = accessLog(metrics.Middleware(http.HandlerFunc(problemHandler.NotFound)))
+	router.MethodNotAllowedHandler = accessLog(metrics.Middleware(http.HandlerFunc(problemHandler.MethodNotAllowed)))
 
 	// Redireciona a rota raiz para o Swagger
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -74,11 +216,70 @@ func main() {
 
 	router.HandleFunc("/alunos", alunoHandler.GetAlunos).Methods("GET")
 	router.HandleFunc("/alunos", alunoHandler.CreateAluno).Methods("POST")
+	router.HandleFunc("/alunos/search", alunoHandler.SearchAlunos).Methods("GET")
+	router.HandleFunc("/alunos/changes", alunoHandler.GetChanges).Methods("GET")
 	router.HandleFunc("/alunos/{id}", alunoHandler.GetAluno).Methods("GET")
 	router.HandleFunc("/alunos/{id}", alunoHandler.UpdateAluno).Methods("PUT")
+	router.HandleFunc("/alunos/{id}", alunoHandler.PatchAluno).Methods("PATCH")
 	router.HandleFunc("/alunos/{id}", alunoHandler.DeleteAluno).Methods("DELETE")
+	router.HandleFunc("/alunos/{id}/restore", alunoHandler.RestoreAluno).Methods("POST")
+	router.HandleFunc("/alunos/{id}/historico", auditoriaHandler.GetHistorico).Methods("GET")
+
+	router.HandleFunc("/alunos/{id}/notas", avaliacaoHandler.GetNotas).Methods("GET")
+	router.HandleFunc("/alunos/{id}/notas", avaliacaoHandler.CreateNota).Methods("POST")
+	router.HandleFunc("/alunos/{id}/notas/{notaId}", avaliacaoHandler.UpdateNota).Methods("PUT")
+	router.HandleFunc("/alunos/{id}/notas/{notaId}", avaliacaoHandler.DeleteNota).Methods("DELETE")
+
+	router.HandleFunc("/professores", professorHandler.GetProfessores).Methods("GET")
+	router.HandleFunc("/professores", professorHandler.CreateProfessor).Methods("POST")
+	router.HandleFunc("/professores/{id}", professorHandler.GetProfessor).Methods("GET")
+	router.HandleFunc("/professores/{id}", professorHandler.UpdateProfessor).Methods("PUT")
+	router.HandleFunc("/professores/{id}", professorHandler.DeleteProfessor).Methods("DELETE")
+
+	router.HandleFunc("/salas", salaHandler.GetSalas).Methods("GET")
+	router.HandleFunc("/salas", salaHandler.CreateSala).Methods("POST")
+	router.HandleFunc("/salas/{id}", salaHandler.GetSala).Methods("GET")
+	router.HandleFunc("/salas/{id}", salaHandler.UpdateSala).Methods("PUT")
+	router.HandleFunc("/salas/{id}", salaHandler.DeleteSala).Methods("DELETE")
+
+	router.HandleFunc("/disciplinas", disciplinaHandler.GetDisciplinas).Methods("GET")
+	router.HandleFunc("/disciplinas", disciplinaHandler.CreateDisciplina).Methods("POST")
+	router.HandleFunc("/disciplinas/{id}", disciplinaHandler.GetDisciplina).Methods("GET")
+	router.HandleFunc("/disciplinas/{id}", disciplinaHandler.UpdateDisciplina).Methods("PUT")
+	router.HandleFunc("/disciplinas/{id}", disciplinaHandler.DeleteDisciplina).Methods("DELETE")
+
+	router.HandleFunc("/auditoria", auditoriaHandler.GetAuditoria).Methods("GET")
+
+	router.HandleFunc("/admin/api-keys", apiKeyHandler.GetAPIKeys).Methods("GET")
+	router.HandleFunc("/admin/api-keys", apiKeyHandler.CreateAPIKey).Methods("POST")
+	router.HandleFunc("/admin/api-keys/{id}", apiKeyHandler.RevokeAPIKey).Methods("DELETE")
+
+	// Verificação de saúde, pública
+	router.HandleFunc("/health", healthHandler.GetHealth).Methods("GET")
+
+	// Métricas para o Prometheus: no endereço interno, quando configurado, ou na porta da API,
+	// protegidas pelo METRICAS_TOKEN
+	if configMetricas.Endereco != "" {
+		metricsRouter := mux.NewRouter()
+		metricsRouter.HandleFunc("/metrics", metricsHandler.GetMetrics).Methods("GET")
+		go func() {
+			log.Info("Métricas disponíveis em " + configMetricas.Endereco)
+			if err := http.ListenAndServe(configMetricas.Endereco, metricsRouter); err != nil {
+				log.WithFields(logrus.Fields{
+					"error": err,
+				}).Fatal("Erro ao iniciar o servidor das métricas")
+			}
+		}()
+	} else {
+		router.HandleFunc("/metrics", metricsHandler.GetMetrics).Methods("GET")
+	}
+
+	// Catálogo dos tipos de problema usados nas respostas de erro
+	router.HandleFunc("/problems", problemHandler.GetProblemTypes).Methods("GET")
+	router.HandleFunc("/problems/{slug}", problemHandler.GetProblemType).Methods("GET")
 
-	// Rota do Swagger
+	// Rota do Swagger, com o documento traduzido conforme o Accept-Language
+	router.HandleFunc("/swagger/doc.json", docsHandler.GetDoc).Methods("GET")
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
 	port := os.Getenv("PORT")
@@ -86,15 +287,17 @@ func main() {
 		port = "8080" // Default fallback
 	}
 	log.Info("Servidor rodando na porta " + port)
-	if err := http.ListenAndServe(":"+port, router); err != nil {
+	// Cria o span de cada requisição, continuando o rastro do traceparent recebido
+	if err := http.ListenAndServe(":"+port, tracingHandler(router)); err != nil {
 		log.WithFields(logrus.Fields{
 			"error": err,
 		}).Fatal("Erro ao iniciar o servidor HTTP")
 	}
 }
 
+// initLogger configura o logger padrão do logrus, usado também fora das requisições (ver logging.FromContext).
 func initLogger() *logrus.Logger {
-	log := logrus.New()
+	log := logrus.StandardLogger()
 	log.SetFormatter(&logrus.JSONFormatter{})
 	log.SetLevel(logrus.InfoLevel)
 	return log
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/httpx"
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+
+	logrus "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serviceName é o nome do serviço nos rastros, quando OTEL_SERVICE_NAME não é configurado.
+const serviceName = "dev-cloud-challenge"
+
+// untracedRoutes são as rotas chamadas periodicamente pela infraestrutura (verificação de
+// saúde e coleta das métricas), que não são rastreadas.
+var untracedRoutes = map[string]bool{"/health": true, "/metrics": true}
+
+// initTracing configura o OpenTelemetry: o contexto de rastreamento é sempre propagado com o
+// cabeçalho W3C traceparent, e os spans são exportados conforme OTEL_TRACES_EXPORTER: none
+// (padrão, sem rastreamento), otlp (HTTP, com as variáveis OTEL_EXPORTER_OTLP_*) ou console (a
+// saída padrão, também aceita como stdout). Sem OTEL_TRACES_SAMPLER, OTEL_TRACES_SAMPLER_ARG
+// define a fração dos rastros iniciados aqui que é amostrada; os rastros iniciados por quem
+// chama a API seguem a decisão do chamador. A função retornada exporta os spans pendentes e
+// deve ser chamada ao encerrar o servidor.
+func initTracing(log *logrus.Logger) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("Erro no rastreamento")
+	}))
+
+	var exporter sdktrace.SpanExporter
+	switch v := os.Getenv("OTEL_TRACES_EXPORTER"); v {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(context.Background())
+	case "console", "stdout":
+		exporter, err = stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER inválido: %q", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithBatcher(exporter)}
+	if v := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); v != "" && os.Getenv("OTEL_TRACES_SAMPLER") == "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG inválido: %q", v)
+		}
+		options = append(options, sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))))
+	}
+
+	// OTEL_SERVICE_NAME e OTEL_RESOURCE_ATTRIBUTES prevalecem sobre o nome padrão
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, sdktrace.WithResource(res))
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// tracingHandler cria, com o otelhttp, o span do servidor de cada requisição, filho do
+// traceparent recebido. O span é nomeado pelo método até que traceRoute encontre a rota.
+func tracingHandler(next http.Handler, options ...otelhttp.Option) http.Handler {
+	options = append([]otelhttp.Option{
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string { return r.Method }),
+		otelhttp.WithFilter(func(r *http.Request) bool { return !untracedRoutes[r.URL.Path] }),
+	}, options...)
+	return otelhttp.NewHandler(next, "", options...)
+}
+
+// traceRoute nomeia o span do servidor pelo método e pelo template da rota (como
+// GET /alunos/{id}) e acrescenta o trace_id e o span_id aos logs da requisição. Deve vir
+// depois do middleware de logging.
+func traceRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := trace.SpanFromContext(r.Context())
+		if route, ok := httpx.RouteTemplate(r); ok {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		// Sem rastreamento, o span do contexto é o do chamador (remoto), que não é registrado
+		if sc := span.SpanContext(); sc.IsValid() && !sc.IsRemote() {
+			logging.AddFields(r.Context(), logrus.Fields{"trace_id": sc.TraceID().String(), "span_id": sc.SpanID().String()})
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/felipemacedo1/dev-cloud-challenge/internal/logging"
+
+	"github.com/gorilla/mux"
+	logrus "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentID = "00f067aa0ba902b7"
+)
+
+func TestTracingPropagation(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		traceparent string
+		wantSpan    bool
+		wantName    string
+		wantRemote  bool
+		wantStatus  codes.Code
+	}{
+		{
+			name: "traceparent amostrado", target: "/alunos/7", traceparent: "00-" + traceID + "-" + parentID + "-01",
+			wantSpan: true, wantName: "GET /alunos/{id}", wantRemote: true,
+		},
+		{name: "traceparent não amostrado", target: "/alunos/7", traceparent: "00-" + traceID + "-" + parentID + "-00"},
+		{name: "sem traceparent", target: "/alunos/7", wantSpan: true, wantName: "GET /alunos/{id}"},
+		{name: "traceparent inválido", target: "/alunos/7", traceparent: "00-" + traceID + "-0000000000000000-01", wantSpan: true, wantName: "GET /alunos/{id}"},
+		{name: "rota inexistente", target: "/nao-existe", wantSpan: true, wantName: "GET"},
+		{name: "erro do servidor", target: "/falha", wantSpan: true, wantName: "GET /falha", wantStatus: codes.Error},
+		{name: "verificação de saúde", target: "/health", traceparent: "00-" + traceID + "-" + parentID + "-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			var out bytes.Buffer
+			log := logrus.New()
+			log.SetOutput(&out)
+			log.SetFormatter(&logrus.JSONFormatter{})
+
+			router := mux.NewRouter()
+			router.Use(logging.Middleware(log))
+			router.Use(traceRoute)
+			ok := func(w http.ResponseWriter, r *http.Request) {}
+			router.HandleFunc("/alunos/{id}", ok)
+			router.HandleFunc("/health", ok)
+			router.HandleFunc("/falha", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) })
+			handler := tracingHandler(router, otelhttp.WithTracerProvider(provider), otelhttp.WithPropagators(propagation.TraceContext{}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			if !tt.wantSpan {
+				if len(spans) != 0 {
+					t.Errorf("recorded %d spans, want none", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != tt.wantName {
+				t.Errorf("span name = %q, want %q", span.Name(), tt.wantName)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+
+			sc, parent := span.SpanContext(), span.Parent()
+			if tt.wantRemote {
+				if sc.TraceID().String() != traceID || parent.SpanID().String() != parentID || !parent.IsRemote() {
+					t.Errorf("span %s/%s, parent %s (remote %v), want trace %s and remote parent %s",
+						sc.TraceID(), sc.SpanID(), parent.SpanID(), parent.IsRemote(), traceID, parentID)
+				}
+			} else {
+				if parent.IsValid() {
+					t.Errorf("span parent = %s, want a new trace", parent.SpanID())
+				}
+				if sc.TraceID().String() == traceID {
+					t.Error("span continues the invalid traceparent")
+				}
+			}
+
+			if tt.wantName == "GET /alunos/{id}" {
+				var route string
+				for _, a := range span.Attributes() {
+					if a.Key == semconv.HTTPRouteKey {
+						route = a.Value.AsString()
+					}
+				}
+				if route != "/alunos/{id}" {
+					t.Errorf("http.route = %q, want /alunos/{id}", route)
+				}
+
+				var access map[string]interface{}
+				if err := json.Unmarshal(out.Bytes(), &access); err != nil {
+					t.Fatalf("decoding access log %q: %v", out.String(), err)
+				}
+				if access["trace_id"] != sc.TraceID().String() || access["span_id"] != sc.SpanID().String() {
+					t.Errorf("access log trace_id/span_id = %v/%v, want %s/%s", access["trace_id"], access["span_id"], sc.TraceID(), sc.SpanID())
+				}
+			}
+		})
+	}
+}
+
+func TestInitTracing(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		arg      string
+		wantErr  bool
+	}{
+		{name: "desabilitado"},
+		{name: "none", exporter: "none"},
+		{name: "exportador desconhecido", exporter: "jaeger", wantErr: true},
+		{name: "fração inválida", exporter: "console", arg: "1.5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_EXPORTER", tt.exporter)
+			t.Setenv("OTEL_TRACES_SAMPLER", "")
+			t.Setenv("OTEL_TRACES_SAMPLER_ARG", tt.arg)
+
+			shutdown, err := initTracing(logrus.New())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("initTracing error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil {
+				shutdown(context.Background())
+			}
+
+			// O traceparent é propagado mesmo sem exportador
+			if fields := otel.GetTextMapPropagator().Fields(); !slices.Contains(fields, "traceparent") {
+				t.Errorf("propagator fields = %v, want traceparent", fields)
+			}
+		})
+	}
+}